@@ -4,8 +4,8 @@ import "encoding/base64"
 
 func asset(key string) (value []byte) {
 	a := map[string]string{
-		"git-build-state":   "IyFiYXNoCiMKIyBnaXQtYnVpbGRfc3RhdGUtY29tcGxldGlvbgojID09PT09PT09PT09PT09PT09PT0KCl9fbXlfaW5pdF9jb21wbGV0aW9uKCkKewogICAgQ09NUFJFUExZPSgpCiAgICBfZ2V0X2NvbXBfd29yZHNfYnlfcmVmIGN1ciBwcmV2IHdvcmRzIGN3b3JkCn0KCgoKX2dpdF9idWlsZF9zdGF0ZSAoKQp7CiAgaWYgW1sgIiRjdXIiID09IC0qIF1dOyB0aGVuCiAgICBfX2dpdGNvbXAgJy1sb2cgLWdlbmVyYXRlLWNyZWRzIC1pbnN0YWxsJwogICAgcmV0dXJuCiAgZmkKICBfX2dpdF9jb21wbGV0ZV9yZXZsaXN0X2ZpbGUKCn0KCmlmIFsgLXogImB0eXBlIC10IF9fZ2l0X2ZpbmRfb25fY21kbGluZWAiIF07IHRoZW4KCWFsaWFzIF9fZ2l0X2ZpbmRfb25fY21kbGluZT1fX2dpdF9maW5kX3N1YmNvbW1hbmQKZmkKCiMgZXg6IHRzPTQgc3c9NCBldCBmaWxldHlwZT1zaAo=",
-		"git-build-state.1": "LlwiIFByb2Nlc3MgdGhpcyBmaWxlIHdpdGgKLlwiIGdyb2ZmIC1tYW4gLVRhc2NpaSBnaXQtYnVpbGQtc3RhdGUuMQouXCIKLmh3IGJ1aWxkLXN0YXRlLmZvcm1hdC5sb2cgYnVpbGQtc3RhdGUuZm9ybWF0LnN0YXRlCi5USCBHSVQtQlVJTEQtU1RBVEUgMSAiTk9WIDIwMTYiIGdpdC1idWlsZC1zdGF0ZSAiVXNlciBNYW51YWxzIgouU0ggTkFNRQpnaXQtYnVpbGQtc3RhdGUgXC0gRGlzcGxheSBidWlsZCBzdGF0ZSBmcm9tIFN0YXNoL0JpdGJ1Y2tldAouXC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tIFNZTk9QU0lTIC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLQouU0ggU1lOT1BTSVMKLkkgZ2l0IGJ1aWxkLXN0YXRlCltvcHRpb25zXSBbLWxvZ10gPGNvbW1pdD4KLlwtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0gREVTQ1JJUFRJT04gLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0KLlNIIERFU0NSSVBUSU9OClNob3cgYnVpbGQgc3RhdGUgc3RvcmVkIGluIFN0YXNoL0JpdGJ1Y2tldCBmb3IgY29tbWl0LgoKQ29tbWl0cyBjYW4gYmUgb24gYW55IGZvcm0gdGhhdCBgZ2l0IHNob3cnIGNhbiB0cmFuc2xhdGUgdG8gYSBjb21taXQuCgpJdCBpcyBhbHNvIHBvc3NpYmxlIHRvIGRpc3BsYXkgYSBgZ2l0IGxvZycgd2l0aCBidWlsZCBzdGF0cyBpbmNsdWRlZC4KLlwtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLSBPUFRJT05TIC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0KLlNIIE9QVElPTlMKLklQIC1sb2cKU2hvdyB0aGUgZ2l0IGxvZyB3aXRoIGJ1aWxkIHN0YXRzIGluY2x1ZGVkLgouSVAgIi1mb3JtYXQgPHRlbXBsYXRlPiIKRm9ybWF0cyB0aGUgb3V0cHV0IHdpdGggR28ncyB0ZXh0L3RlbXBsYXRlLiBTZWUgXGZJIGJ1aWxkLXN0YXRlLmZvcm1hdC5sb2cgXGZSIGFuZCBcZkkgYnVpbGQtc3RhdGUuZm9ybWF0LnN0YXRlIFxmUiBmb3IgbW9yZSBpbmZvcm1hdGlvbi4KLklQIC1qc29uCkZvcm1hdCBvdXRwdXQgYXMgSlNPTi4KLklQIC1pbnN0YWxsClNldHMgdXAgQmFzaCBjb21wbGV0aW9uLCBtYW51YWwgbWFwYWdlcywgYW5kIGF1dGhlbnRpY2F0aW9uCi5JUCAiLXByb3RvIDxodHRwfGh0dHBzPiIKT3ZlcnJpZGUgdGhlIHByb3RvY29sbCB1c2VkIHdpdGggU3Rhc2gvQml0YnVja2V0LiBUaGlzIHNob3VsZCBvbmx5IGJlIHVzZWQgZm9yIGRldmVsb3BtZW50LgouSVAgLWdlbmVyYXRlLWNyZWRzClVzZSB0aGlzIGZvciBnZW5lcmF0aW5nIGNyZWRlbnRpYWxzIG5lY2Vzc2FyeSB0byBjb21tdW5pY2F0ZSB3aXRoIFN0YXNoL0JpdGJ1Y2tldAoKLlwtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLSBDT05GSUdVUkFUSU9OIC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0KLlNIIENPTkZJR1VSQVRJT04KQ29uZmlndXJhdGlvbiBpcyBkb25lIHdpdGggYGdpdCBjb25maWdgLiBFeGFtcGxlIHRvIHNldCBidWlsZC1zdGF0ZS5hdXRoLnVzZXIgY29uZmlndXJhdGlvbjoKLlJTCi5CIGdpdCBjb25maWcgLS1nbG9iYWwgYnVpbGQtc3RhdGUuYXV0aC51c2VyIHVzZXJAZXhhbXBsZS5jb20KLlJFCgouSSBidWlsZC1zdGF0ZS5hdXRoLnVzZXIKLlJTClRoZSB1c2VybmFtZSBmb3IgYXV0aGVudGljYXRpb25zCi5SRQoKLkkgYnVpbGQtc3RhdGUuYXV0aC5jcmVkZW50aWFscwouUlMKQmFzZTY0IGVuY29kZWQgc3RyaW5nIG9mIHVzZXJuYW1lIGFuZCBwYXNzd29yZC4gRW5jb2RlZCBvbiB0aGUgZm9ybSBcZkkgdXNlcm5hbWU6cGFzc3dvcmRcZlIuIFRoaXMgbWlnaHQgc2VlbSBpbnNlY3VyZSwgaG93ZXZlciBpdCBzaG91bGQgbm90IGJlIHdvcnNlIHRoZSBoYXZpbmcgYSB1bmVuY3J5cHRlZCB0b2tlbiBzYXZlZC4KLlJFCgouSSBidWlsZC1zdGF0ZS5lbmRwb2ludAouUlMKTm9ybWFseSB0aGUgU3Rhc2gvQml0YnVja2V0IFVSTCBpcyBpbmZlcnJlZCBmcm9tIHRoZSBnaXQgcmVtb3RlIHNldHRpbmcuIFRoaXMgc2V0dGluZyB3aWxsIG92ZXIgcmlkZSB0aGF0LiBXcml0dGVuIG9uIHRoZSBmb3JtIFxmSSBodHRwczovL2V4YW1wbGUuY29tCi5SRQoKLkkgYnVpbGQtc3RhdGUucG9ydAouUlMKRGVmaW5lcyB0aGUgcG9ydCBmb3IgdGhlIFN0YXNoL0JpdGJ1Y2tldCBBUEkKLlJFCgouSSBidWlsZC1zdGF0ZS5mb3JtYXQubG9nCi5SUwpUZW1wbGF0ZSBkZWZpbml0aW9uIG9mIHRoZSBvdXRwdXQgZm9yIHRoZSBsb2cuIFRoZSBkZWZhdWx0IHRlbXBsYXRlIGRlZmluaXRpb246Ci5uZgp7ey5JRH19IHt7Lk1lc3NhZ2V9fQogICBTdWNjZXNzZnVsOiB7ey5TdGF0dXMuU3VjY2Vzc2Z1bH19LAogICBJbiBQcm9ncmVzczoge3suU3RhdHVzLkluUHJvZ3Jlc3N9fSwKICAgRmFpbGVkOiB7ey5TdGF0dXMuRmFpbGVkfX0KLmZpCi5SRQoKLkkgYnVpbGQtc3RhdGUuZm9ybWF0LnN0YXRlCi5SUwpUZW1wbGF0ZSBkZWZpbml0aW9uIG9mIHRoZSBvdXRwdXQgZm9yIHRoZSBidWlsZCBzdGF0ZS4gVGhlIGRlZmF1bHQgdGVtcGxhdGUgZGVmaW5pdGlvbjoKCi5uZgpOYW1lOiAge3suTmFtZX19ICAgICBLZXk6IHt7LktleX19ClN0YXRlOiB7ey5TdGF0ZX19ClVSTDogICB7ey5VUkx9fQpEYXRlOiAge3suRGF0ZUFkZGVkfX0KCiAgIHt7LkRlc2NyaXB0aW9ufX0KLmZpCi5SRQouXC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tIEFVVEhPUiAtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLQouU0ggQVVUSE9SCk5pbHMgTGFnZXJrdmlzdCA8bmlscyBkb3QgbGFnZXJrdmlzdCBhdCBnbWFpbCBkb3QgY29tPgo=",
+		"git-build-state":   "IyFiYXNoCiMKIyBnaXQtYnVpbGRfc3RhdGUtY29tcGxldGlvbgojID09PT09PT09PT09PT09PT09PT0KCl9fbXlfaW5pdF9jb21wbGV0aW9uKCkKewogICAgQ09NUFJFUExZPSgpCiAgICBfZ2V0X2NvbXBfd29yZHNfYnlfcmVmIGN1ciBwcmV2IHdvcmRzIGN3b3JkCn0KCgoKX2dpdF9idWlsZF9zdGF0ZSAoKQp7CiAgaWYgW1sgIiRjdXIiID09IC0qIF1dOyB0aGVuCiAgICBfX2dpdGNvbXAgJy1sb2cgLWdyYXBoIC1uIC1maXJzdC1wYXJlbnQgLXNpbmNlIC11bnRpbCAtYXV0aG9yIC1nZW5lcmF0ZS1jcmVkcyAtaW5zdGFsbCAtanNvbiAtY29sb3IgLWZvcm1hdCAtcmVtb3RlIC1wYWdlLXNpemUgLW5vLWNhY2hlIC1yZWZyZXNoIC1wcm9tcHQgLWNoZWNrIC13YWl0IC10aW1lb3V0IC1pbnRlcnZhbCAtc2V0IC1rZXkgLXN0YXRlIC11cmwgLW5hbWUgLWRlc2NyaXB0aW9uJwogICAgcmV0dXJuCiAgZmkKICBfX2dpdF9jb21wbGV0ZV9yZXZsaXN0X2ZpbGUKCn0KCmlmIFsgLXogImB0eXBlIC10IF9fZ2l0X2ZpbmRfb25fY21kbGluZWAiIF07IHRoZW4KCWFsaWFzIF9fZ2l0X2ZpbmRfb25fY21kbGluZT1fX2dpdF9maW5kX3N1YmNvbW1hbmQKZmkKCiMgZXg6IHRzPTQgc3c9NCBldCBmaWxldHlwZT1zaAo=",
+		"git-build-state.1": "LlwiIFByb2Nlc3MgdGhpcyBmaWxlIHdpdGgKLlwiIGdyb2ZmIC1tYW4gLVRhc2NpaSBnaXQtYnVpbGQtc3RhdGUuMQouXCIKLmh3IGJ1aWxkLXN0YXRlLmZvcm1hdC5sb2cgYnVpbGQtc3RhdGUuZm9ybWF0LnN0YXRlIGJ1aWxkLXN0YXRlLmZvcm1hdC5wcm9tcHQKLlRIIEdJVC1CVUlMRC1TVEFURSAxICJOT1YgMjAxNiIgZ2l0LWJ1aWxkLXN0YXRlICJVc2VyIE1hbnVhbHMiCi5TSCBOQU1FCmdpdC1idWlsZC1zdGF0ZSBcLSBEaXNwbGF5IGJ1aWxkIHN0YXRlIGZyb20gU3Rhc2gvQml0YnVja2V0Ci5cLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0gU1lOT1BTSVMgLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tCi5TSCBTWU5PUFNJUwouSSBnaXQgYnVpbGQtc3RhdGUKW29wdGlvbnNdIDxjb21taXQ+Ci5icgouSSBnaXQgYnVpbGQtc3RhdGUKW29wdGlvbnNdIC1sb2cgWy1ncmFwaF0gWy1uIDxudW1iZXI+XSBbLWZpcnN0LXBhcmVudF0gWy1zaW5jZSA8ZGF0ZT5dIFstdW50aWwgPGRhdGU+XSBbLWF1dGhvciA8cGF0dGVybj5dIFs8cmV2aXNpb24gcmFuZ2U+XSBbWy0tXSA8cGF0aD4uLi5dCi5icgouSSBnaXQgYnVpbGQtc3RhdGUKLXdhaXQgWy10aW1lb3V0IDxkdXJhdGlvbj5dIFstaW50ZXJ2YWwgPGR1cmF0aW9uPl0gPGNvbW1pdD4KLmJyCi5JIGdpdCBidWlsZC1zdGF0ZQotc2V0IC1rZXkgPGtleT4gLXN0YXRlIDxzdGF0ZT4gLXVybCA8dXJsPiBbLW5hbWUgPG5hbWU+XSBbLWRlc2NyaXB0aW9uIDx0ZXh0Pl0gPGNvbW1pdD4KLmJyCi5JIGdpdCBidWlsZC1zdGF0ZQotcHJvbXB0Ci5icgouSSBnaXQgYnVpbGQtc3RhdGUKcnVuIC1rZXkgPGtleT4gWy1uYW1lIDxuYW1lPl0gWy11cmwgPHVybD5dIC0tIDxjb21tYW5kPiBbPGFyZ3M+Li4uXQouXC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLSBERVNDUklQVElPTiAtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLQouU0ggREVTQ1JJUFRJT04KU2hvdyBidWlsZCBzdGF0ZSBzdG9yZWQgaW4gU3Rhc2gvQml0YnVja2V0IGZvciBjb21taXQuCgpDb21taXRzIGNhbiBiZSBvbiBhbnkgZm9ybSB0aGF0IGBnaXQgc2hvdycgY2FuIHRyYW5zbGF0ZSB0byBhIGNvbW1pdC4KCkl0IGlzIGFsc28gcG9zc2libGUgdG8gZGlzcGxheSBhIGBnaXQgbG9nJyB3aXRoIGJ1aWxkIHN0YXRzIGluY2x1ZGVkLiBUaGUgY29tbWl0cyBhcmUgc2VsZWN0ZWQgYXMgd2l0aCBgZ2l0IGxvZycsIHJldmlzaW9uIHJhbmdlcyBzdWNoIGFzIFxmSSBtYWluLi5mZWF0dXJlIFxmUiBhbmQgcGF0aHMgYWZ0ZXIgXGZJIC0tIFxmUiBhcmUgcGFzc2VkIHRocm91Z2gsIGFuZCBkZWZhdWx0IHRvIHRoZSBjdXJyZW50IGJyYW5jaC4KCldpdGggXGZJIC1zZXQgXGZSIHRoZSBidWlsZCBzdGF0ZSBvZiBhIGNvbW1pdCBpcyB3cml0dGVuIHRvIFN0YXNoL0JpdGJ1Y2tldCBpbnN0ZWFkLgoKV2l0aCBcZkkgLXdhaXQgXGZSIHRoZSBidWlsZCBzdGF0ZSBpcyBwb2xsZWQgdW50aWwgbm8gYnVpbGQgaXMgaW4gcHJvZ3Jlc3MsIGFuZCB0aGUgZXhpdCBjb2RlIHRlbGxzIHRoZSBvdXRjb21lLiBDb21taXRzIHdpdGhvdXQgYW55IGJ1aWxkcyByZXBvcnRlZCBhcmUgd2FpdGVkIGZvciBhcyB3ZWxsLCBhcyB0aGUgYnVpbGRzIG1pZ2h0IG5vdCBoYXZlIHN0YXJ0ZWQgeWV0LgoKVGhlIFxmSSBydW4gXGZSIHN1YmNvbW1hbmQgd3JhcHMgYSBjb21tYW5kIGFuZCByZXBvcnRzIGl0cyBvdXRjb21lIGFzIGEgYnVpbGQgc3RhdGUgZm9yIEhFQUQuIFRoZSBzdGF0ZSBpcyBzZXQgdG8gSU5QUk9HUkVTUyB3aGlsZSB0aGUgY29tbWFuZCBydW5zLCBhbmQgdG8gU1VDQ0VTU0ZVTCBvciBGQUlMRUQgd2l0aCB0aGUgZHVyYXRpb24gYW5kIGV4aXQgY29kZSBpbiB0aGUgZGVzY3JpcHRpb24gd2hlbiBpdCBmaW5pc2hlcy4gVGhlIG91dHB1dCBvZiB0aGUgY29tbWFuZCBpcyBzdHJlYW1lZCBhbmQgZ2l0LWJ1aWxkLXN0YXRlIGV4aXRzIHdpdGggdGhlIGV4aXQgY29kZSBvZiB0aGUgY29tbWFuZC4gVGhlIG5hbWUgZGVmYXVsdHMgdG8gdGhlIGNvbW1hbmQgYW5kIHRoZSBVUkwgdG8gdGhlIFN0YXNoL0JpdGJ1Y2tldCBVUkwuIFRoZSBzdWJjb21tYW5kIGlzIG9ubHkgcmVjb2duaXplZCB3aGVuIFxmSSBydW4gXGZSIGlzIGZvbGxvd2VkIGJ5IGEgZmxhZyBvciBcZkkgLS1cZlIsIG90aGVyd2lzZSBcZkkgcnVuIFxmUiBpcyB0YWtlbiBhcyBhIGNvbW1pdCwgc28gdGhlIGJ1aWxkIHN0YXRlIG9mIGEgYnJhbmNoIG5hbWVkIHJ1biBjYW4gc3RpbGwgYmUgc2hvd24gd2l0aCBcZkkgZ2l0IGJ1aWxkLXN0YXRlIHJ1blxmUi4KLlwtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLSBPUFRJT05TIC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0KLlNIIE9QVElPTlMKLklQIC1sb2cKU2hvdyB0aGUgZ2l0IGxvZyB3aXRoIGJ1aWxkIHN0YXRzIGluY2x1ZGVkLgouSVAgLWdyYXBoCkRyYXcgdGhlIGNvbW1pdCBncmFwaCBhcyBcZkkgZ2l0IGxvZyAtLWdyYXBoIFxmUiBkb2VzLCB3aXRoIGEgYmFkZ2Ugb2YgdGhlIGJ1aWxkIHN0YXRzIG5leHQgdG8gZWFjaCBjb21taXQsIGUuZy4gXCh1MjcxNDMgXCh1MjVDRjEgXCh1MjcxODAgZm9yIHN1Y2Nlc3NmdWwsIGluIHByb2dyZXNzIGFuZCBmYWlsZWQgYnVpbGRzLiBVc2VkIHdpdGggXGZJIC1sb2dcZlIsIHRoZSBmb3JtYXQgdGVtcGxhdGVzIGRvIG5vdCBhcHBseS4KLklQICItbiA8bnVtYmVyPiIKTnVtYmVyIG9mIGNvbW1pdHMgdG8gc2hvdywgdXNlZCB3aXRoIFxmSSAtbG9nXGZSLiBEZWZhdWx0cyB0byA4LgouSVAgLWZpcnN0LXBhcmVudApGb2xsb3cgb25seSB0aGUgZmlyc3QgcGFyZW50IG9mIG1lcmdlIGNvbW1pdHMsIHVzZWQgd2l0aCBcZkkgLWxvZ1xmUi4KLklQICItc2luY2UgPGRhdGU+LCAtdW50aWwgPGRhdGU+IgpTaG93IGNvbW1pdHMgbW9yZSByZWNlbnQgb3Igb2xkZXIgdGhhbiBhIGRhdGUsIHVzZWQgd2l0aCBcZkkgLWxvZ1xmUi4KLklQICItYXV0aG9yIDxwYXR0ZXJuPiIKU2hvdyBjb21taXRzIGJ5IGF1dGhvcnMgbWF0Y2hpbmcgdGhlIHBhdHRlcm4sIHVzZWQgd2l0aCBcZkkgLWxvZ1xmUi4KLklQICItZm9ybWF0IDx0ZW1wbGF0ZXxuYW1lOjxuYW1lPnxAPGZpbGU+PiIKRm9ybWF0cyB0aGUgb3V0cHV0IHdpdGggR28ncyB0ZXh0L3RlbXBsYXRlLiBUaGUgdGVtcGxhdGUgbWF5IGJlIGdpdmVuIGRpcmVjdGx5LCBzZWxlY3RlZCBieSBuYW1lIHdpdGggXGZJIG5hbWU6PG5hbWU+XGZSLCBvciByZWFkIGZyb20gYSBmaWxlIHdpdGggXGZJIEA8ZmlsZT5cZlIuIE5hbWVzIGFyZSB0aGUgYnVpbHQtaW4gZm9ybWF0cyBcZkkgb25lbGluZVxmUiwgXGZJIHNob3J0XGZSLCBcZkkgZnVsbCBcZlIgYW5kIFxmSSBwcm9tcHRcZlIsIG9yIGZvcm1hdHMgZGVmaW5lZCB3aXRoIFxmSSBidWlsZC1zdGF0ZS5wcmV0dHkuPG5hbWU+XGZSLCBhbmQgbWF5IGFsc28gYmUgZ2l2ZW4gd2l0aG91dCBcZkkgbmFtZTpcZlIuIFNlZSBcZkkgYnVpbGQtc3RhdGUuZm9ybWF0LmxvZyBcZlIgYW5kIFxmSSBidWlsZC1zdGF0ZS5mb3JtYXQuc3RhdGUgXGZSIGZvciBtb3JlIGluZm9ybWF0aW9uLgouSVAgLWpzb24KRm9ybWF0IG91dHB1dCBhcyBKU09OLgouSVAgIi1jb2xvciA8YXV0b3xhbHdheXN8bmV2ZXI+IgpDb2xvdXIgdGhlIGJ1aWxkIHN0YXRlcyBhbmQgbWFrZSBidWlsZCBVUkxzIGFuZCBjb21taXQgSURzIGh5cGVybGlua3MuIFdpdGggXGZJIGF1dG9cZlIsIHRoZSBkZWZhdWx0LCBjb2xvdXIgaXMgZGlzYWJsZWQgd2hlbiBOT19DT0xPUiBpcyBzZXQsIGFuZCBvdGhlcndpc2UgZGVjaWRlZCBieSBcZkkgY29sb3IuYnVpbGQtc3RhdGUgXGZSIGFuZCBcZkkgY29sb3IudWlcZlIsIHdoaWNoIGNvbG91ciBvdXRwdXQgdG8gdGVybWluYWxzLgouSVAgIi1yZW1vdGUgPG5hbWU+IgpUaGUgZ2l0IHJlbW90ZSB1c2VkIHRvIGluZmVyIHRoZSBTdGFzaC9CaXRidWNrZXQgVVJMIGFuZCByZXBvc2l0b3J5LCBzZWUgXGZJIGJ1aWxkLXN0YXRlLnJlbW90ZVxmUi4KLklQICItcGFnZS1zaXplIDxuPiIKTnVtYmVyIG9mIGJ1aWxkIHN0YXR1c2VzIHRvIHJlcXVlc3QgcGVyIHBhZ2UuIEFsbCBwYWdlcyBhcmUgYWx3YXlzIGZldGNoZWQsIHNlZSBcZkkgYnVpbGQtc3RhdGUucGFnZVNpemUgXGZSLgouSVAgLWNoZWNrClByaW50IGEgb25lIGxpbmUgc3VtbWFyeSBvZiB0aGUgYnVpbGQgc3RhdGUgYW5kIGV4aXQgd2l0aCBhIGNvZGUgcmVmbGVjdGluZyBpdCwgc2VlIFxmSSBFWElUIFNUQVRVU1xmUi4gVG9nZXRoZXIgd2l0aCBcZkkgLWxvZyBcZlIgdGhlIG5ld2VzdCBjb21taXQgb2YgdGhlIGxvZyBpcyBjaGVja2VkLgouSVAgLXdhaXQKV2FpdCB1bnRpbCBidWlsZHMgaGF2ZSBiZWVuIHJlcG9ydGVkIGFuZCBubyBidWlsZCBpcyBpbiBwcm9ncmVzcywgdGhlbiBkaXNwbGF5IHRoZSBidWlsZCBzdGF0ZS4gRXhpdHMgd2l0aCAwIGlmIGFsbCBidWlsZHMgYXJlIHN1Y2Nlc3NmdWwsIDEgaWYgYW55IGJ1aWxkIGZhaWxlZCwgMiBvbiB0aW1lb3V0IGFuZCA0IG9uIGVycm9ycy4KLklQICItdGltZW91dCA8ZHVyYXRpb24+IgpNYXhpbXVtIHRpbWUgdG8gd2FpdCwgdXNlZCB3aXRoIFxmSSAtd2FpdFxmUi4gRGVmYXVsdHMgdG8gMzBtLgouSVAgIi1pbnRlcnZhbCA8ZHVyYXRpb24+IgpJbml0aWFsIHBvbGwgaW50ZXJ2YWwsIHVzZWQgd2l0aCBcZkkgLXdhaXRcZlIuIFRoZSBpbnRlcnZhbCBncm93cyB1cCB0byBmb3VyIHRpbWVzIHRoaXMgdmFsdWUuIERlZmF1bHRzIHRvIDE1cy4KLklQIC1zZXQKU2V0IHRoZSBidWlsZCBzdGF0ZSBvZiB0aGUgY29tbWl0LiBSZXF1aXJlcyBcZkkgLWtleVxmUiwgXGZJIC1zdGF0ZSBcZlIgYW5kIFxmSSAtdXJsXGZSLgouSVAgIi1rZXkgPGtleT4iCktleSBpZGVudGlmeWluZyB0aGUgYnVpbGQsIHVzZWQgd2l0aCBcZkkgLXNldFxmUi4gU2V0dGluZyBhIHN0YXRlIGZvciBhbiBleGlzdGluZyBrZXkgcmVwbGFjZXMgaXQuCi5JUCAiLXN0YXRlIDxJTlBST0dSRVNTfFNVQ0NFU1NGVUx8RkFJTEVEPiIKVGhlIGJ1aWxkIHN0YXRlLCB1c2VkIHdpdGggXGZJIC1zZXRcZlIuCi5JUCAiLXVybCA8dXJsPiIKVVJMIHRvIHRoZSBidWlsZCwgdXNlZCB3aXRoIFxmSSAtc2V0XGZSLgouSVAgIi1uYW1lIDxuYW1lPiIKRGlzcGxheSBuYW1lIG9mIHRoZSBidWlsZCwgdXNlZCB3aXRoIFxmSSAtc2V0XGZSLgouSVAgIi1kZXNjcmlwdGlvbiA8dGV4dD4iCkRlc2NyaXB0aW9uIG9mIHRoZSBidWlsZCwgdXNlZCB3aXRoIFxmSSAtc2V0XGZSLgouSVAgLW5vLWNhY2hlCk5laXRoZXIgcmVhZCBub3Igd3JpdGUgdGhlIGJ1aWxkIHN0YXRlIGNhY2hlLCBzZWUgXGZJIGJ1aWxkLXN0YXRlLmNhY2hlLnR0bFxmUi4KLklQIC1yZWZyZXNoCkZldGNoIGJ1aWxkIHN0YXRlcyBldmVuIGlmIHRoZXkgYXJlIGNhY2hlZCwgdGhlIGNhY2hlIGlzIHVwZGF0ZWQgd2l0aCB0aGUgcmVzdWx0LiBJbXBsaWVkIGJ5IFxmSSAtY2hlY2sgXGZSIGFuZCBcZkkgLXdhaXRcZlIsIHdoaWNoIGFsd2F5cyByZXBvcnQgdGhlIGN1cnJlbnQgc3RhdGUgb24gdGhlIHNlcnZlci4KLklQIC1wcm9tcHQKUHJpbnQgYSBzaG9ydCB0b2tlbiB3aXRoIHRoZSBidWlsZCBzdGF0ZSBvZiBIRUFEIGZvciBzaGVsbCBwcm9tcHRzLCBlLmcuIFxmSSBcKHUyNzE4MVwodTI1Q0YxXCh1MjcxNDMgXGZSIGZvciBvbmUgZmFpbGVkLCBvbmUgaW4gcHJvZ3Jlc3MgYW5kIHRocmVlIHN1Y2Nlc3NmdWwgYnVpbGRzLiBUaGUgbGFzdCBrbm93biBzdGF0ZSBpcyBwcmludGVkIGltbWVkaWF0ZWx5IGFuZCByZWZyZXNoZWQgYnkgYSBiYWNrZ3JvdW5kIHByb2Nlc3MsIHNvIHRoZSBwcm9tcHQgbmV2ZXIgd2FpdHMgZm9yIHRoZSBzZXJ2aWNlLiBUaGUgcHJvbXB0IHdhaXRzIGZvciB0aGUgc3RhdGUgb2YgYSBuZXcgSEVBRCB1bnRpbCBcZkkgYnVpbGQtc3RhdGUucHJvbXB0LnRpbWVvdXQgXGZSIGhhcyBwYXNzZWQgc2luY2UgaXQgc3RhcnRlZCwgdGhlIGdpdCBjb21tYW5kcyBpdCBydW5zIHRvIGZpbmQgSEVBRCBhbmQgcmVhZCBpdHMgY29uZmlndXJhdGlvbiBjb3VudCBhZ2FpbnN0IHRoaXMgdGltZS4gVGhlIHRva2VuIGlzIG9ubHkgY29sb3VyZWQgd2l0aCBcZkkgLWNvbG9yPWFsd2F5c1xmUi4gTm90aGluZyBpcyBwcmludGVkIG91dHNpZGUgb2YgZ2l0IHJlcG9zaXRvcmllcywgZm9yIGNvbW1pdHMgd2l0aG91dCBidWlsZHMsIG9yIGJlZm9yZSB0aGUgc3RhdGUgb2YgYSBuZXcgSEVBRCBpcyBrbm93bi4gV2hlbiB0aGUgcmVmcmVzaCBmYWlscywgZS5nLiBpbiByZXBvc2l0b3JpZXMgd2l0aG91dCBhIHNlcnZpY2UsIGl0IGlzIG5vdCByZXRyaWVkIGZvciBhIG1pbnV0ZS4gVGhlIHRva2VuIGlzIGZvcm1hdHRlZCB3aXRoIFxmSSAtZm9ybWF0IFxmUiBvciBcZkkgYnVpbGQtc3RhdGUuZm9ybWF0LnByb21wdFxmUi4gRm9yIGV4YW1wbGUgaW4gYmFzaDogXGZJIFBTMT0nXFx3ICQoZ2l0IGJ1aWxkLXN0YXRlIC1wcm9tcHQpIFxcJCAnXGZSLgouSVAgLWluc3RhbGwKU2V0cyB1cCBCYXNoIGNvbXBsZXRpb24sIG1hbnVhbCBtYXBhZ2VzLCBhbmQgYXV0aGVudGljYXRpb24KLklQICItcHJvdG8gPGh0dHB8aHR0cHM+IgpPdmVycmlkZSB0aGUgcHJvdG9jb2xsIHVzZWQgd2l0aCBTdGFzaC9CaXRidWNrZXQuIFRoaXMgc2hvdWxkIG9ubHkgYmUgdXNlZCBmb3IgZGV2ZWxvcG1lbnQuCi5JUCAtZ2VuZXJhdGUtY3JlZHMKVXNlIHRoaXMgZm9yIGdlbmVyYXRpbmcgY3JlZGVudGlhbHMgbmVjZXNzYXJ5IHRvIGNvbW11bmljYXRlIHdpdGggU3Rhc2gvQml0YnVja2V0CgouXC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLSBDUkVERU5USUFMUyAtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLQouU0ggQ1JFREVOVElBTFMKQ3JlZGVudGlhbHMgYXJlIGxvb2tlZCB1cCBpbiB0aGUgZm9sbG93aW5nIG9yZGVyLCB0aGUgZmlyc3QgbWF0Y2ggaXMgdXNlZDoKLklQIDEuIDQKVGhlIGVudmlyb25tZW50IHZhcmlhYmxlIFxmSSBHSVRfQlVJTERfU1RBVEVfVE9LRU5cZlIsIHNlbnQgYXMgYSBiZWFyZXIgdG9rZW4sIG9yIFxmSSBHSVRfQlVJTERfU1RBVEVfVVNFUiBcZlIgYW5kIFxmSSBHSVRfQlVJTERfU1RBVEVfUEFTU1dPUkRcZlIuIFRoZSB1c2VyIGRlZmF1bHRzIHRvIFxmSSBidWlsZC1zdGF0ZS5hdXRoLnVzZXJcZlIuCi5JUCAyLiA0ClRoZSBlbnRyeSBpbiBcZkkgJE5FVFJDIFxmUiBvciBcZkkgfi8ubmV0cmMgXGZSIG1hdGNoaW5nIHRoZSBBUEkgaG9zdCwgb3IgaXRzIGRlZmF1bHQgZW50cnkuCi5JUCAzLiA0ClRoZSBnaXQgY29uZmlndXJhdGlvbiBzZWxlY3RlZCBieSBcZkkgYnVpbGQtc3RhdGUuYXV0aC50eXBlXGZSLiBFYWNoIFxmSSBidWlsZC1zdGF0ZS5hdXRoLjxuYW1lPiBcZlIgc2V0dGluZyBtYXkgYmUgZ2l2ZW4gZm9yIGEgVVJMIGFzIFxmSSBidWlsZC1zdGF0ZS48dXJsPi5hdXRoLjxuYW1lPlxmUiwgZS5nLiBcZkkgZ2l0IGNvbmZpZyAtLWdsb2JhbCBidWlsZC1zdGF0ZS5odHRwczovL2dpdGxhYi5leGFtcGxlLmNvbS5hdXRoLnRva2VuIDx0b2tlbj5cZlIuIFRoZSBtb3N0IHNwZWNpZmljIFVSTCBtYXRjaGluZyB0aGUgQVBJIFVSTCBpcyB1c2VkLCB0aGUgc2NoZW1lIG1heSBiZSBsZWZ0IG91dC4gVGhlIHNldHRpbmdzIHdpdGhvdXQgYSBVUkwsIGFzIHdyaXR0ZW4gYnkgXGZJIC1pbnN0YWxsIFxmUiBhbmQgXGZJIC1nZW5lcmF0ZS1jcmVkc1xmUiwgYXJlIG9ubHkgdXNlZCBmb3IgU3Rhc2gvQml0YnVja2V0IFNlcnZlciwgc28gaXRzIGNyZWRlbnRpYWxzIGFyZSBuZXZlciBzZW50IHRvIG90aGVyIHNlcnZpY2VzLgouUFAKUmVxdWVzdHMgYXJlIHNlbnQgdW5hdXRoZW50aWNhdGVkIHdoZW4gbm8gY3JlZGVudGlhbHMgYXJlIGZvdW5kLiBSdW4gd2l0aCBcZkkgLWRlYnVnIFxmUiB0byBzZWUgd2hpY2ggc291cmNlIHdhcyB1c2VkLgoKLlwtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0gRVhJVCBTVEFUVVMgLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0KLlNIIEVYSVQgU1RBVFVTCldpdGggXGZJIC1jaGVjayBcZlIgYW5kIFxmSSAtd2FpdCBcZlIgdGhlIGV4aXQgc3RhdHVzIHJlZmxlY3RzIHRoZSBidWlsZCBzdGF0ZToKLklQIDAKQWxsIGJ1aWxkcyBhcmUgc3VjY2Vzc2Z1bC4KLklQIDEKQXQgbGVhc3Qgb25lIGJ1aWxkIGZhaWxlZC4KLklQIDIKQXQgbGVhc3Qgb25lIGJ1aWxkIGlzIGluIHByb2dyZXNzLCBvciBcZkkgLXdhaXQgXGZSIHRpbWVkIG91dC4KLklQIDMKTm8gYnVpbGRzIGhhdmUgYmVlbiByZXBvcnRlZCBmb3IgdGhlIGNvbW1pdC4KLklQIDQKVGhlIGJ1aWxkIHN0YXRlIGNvdWxkIG5vdCBiZSBkZXRlcm1pbmVkLCBlLmcuIHRoZSBjb21taXQgaXMgbm90IHZhbGlkLCBvciB0aGUgc2VydmljZSBjb3VsZCBub3QgYmUgcmVhY2hlZCBvciByZWplY3RlZCB0aGUgY3JlZGVudGlhbHMuCi5QUApPdGhlciBtb2RlcyBleGl0IHdpdGggMCBvbiBzdWNjZXNzIGFuZCBhIG5vbi16ZXJvIHN0YXR1cyBvbiBlcnJvcnMuCgouXC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tIENPTkZJR1VSQVRJT04gLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLQouU0ggQ09ORklHVVJBVElPTgpDb25maWd1cmF0aW9uIGlzIGRvbmUgd2l0aCBgZ2l0IGNvbmZpZ2AuIEV4YW1wbGUgdG8gc2V0IGJ1aWxkLXN0YXRlLmF1dGgudXNlciBjb25maWd1cmF0aW9uOgouUlMKLkIgZ2l0IGNvbmZpZyAtLWdsb2JhbCBidWlsZC1zdGF0ZS5hdXRoLnVzZXIgdXNlckBleGFtcGxlLmNvbQouUkUKCi5JIGJ1aWxkLXN0YXRlLmF1dGgudHlwZQouUlMKVGhlIGF1dGhlbnRpY2F0aW9uIHR5cGUsIFxmSSBiYXNpYyBcZlIgKGRlZmF1bHQpIHVzZXMgXGZJIGJ1aWxkLXN0YXRlLmF1dGgudXNlciBcZlIgYW5kIFxmSSBidWlsZC1zdGF0ZS5hdXRoLmNyZWRlbnRpYWxzXGZSLiBcZkkgdG9rZW4gXGZSIChvciBcZkkgYmVhcmVyXGZSKSBzZW5kcyBcZkkgYnVpbGQtc3RhdGUuYXV0aC50b2tlbiBcZlIgYXMgYSBiZWFyZXIgdG9rZW4uIFxmSSBjcmVkZW50aWFsIFxmUiBvYnRhaW5zIHRoZSB1c2VybmFtZSBhbmQgcGFzc3dvcmQgdGhyb3VnaCBgZ2l0IGNyZWRlbnRpYWwgZmlsbCcsIHVzaW5nIHdoYXRldmVyIGNyZWRlbnRpYWwgaGVscGVyIGlzIGNvbmZpZ3VyZWQsIHNlZSBcZkIgZ2l0Y3JlZGVudGlhbHNcZlIoNykuIE5vdGhpbmcgaXMgc3RvcmVkIGluIGdpdCBjb25maWcsIGNyZWRlbnRpYWxzIGFyZSBhcHByb3ZlZCB3aGVuIGFjY2VwdGVkIGFuZCByZWplY3RlZCB3aGVuIHRoZSBzZXJ2ZXIgcmVmdXNlcyB0aGVtLiBUaGUgdHlwZSBpcyBhc2tlZCBmb3IgYnkgXGZJIC1pbnN0YWxsIFxmUiBhbmQgXGZJIC1nZW5lcmF0ZS1jcmVkc1xmUi4KLlJFCgouSSBidWlsZC1zdGF0ZS5hdXRoLnRva2VuCi5SUwpQZXJzb25hbCBvciBIVFRQIGFjY2VzcyB0b2tlbiB1c2VkIHdoZW4gXGZJIGJ1aWxkLXN0YXRlLmF1dGgudHlwZSBcZlIgaXMgXGZJIHRva2VuXGZSLgouUkUKCi5JIGJ1aWxkLXN0YXRlLmF1dGgudXNlcgouUlMKVGhlIHVzZXJuYW1lIGZvciBhdXRoZW50aWNhdGlvbnMKLlJFCgouSSBidWlsZC1zdGF0ZS5hdXRoLmNyZWRlbnRpYWxzCi5SUwpCYXNlNjQgZW5jb2RlZCBzdHJpbmcgb2YgdXNlcm5hbWUgYW5kIHBhc3N3b3JkLiBFbmNvZGVkIG9uIHRoZSBmb3JtIFxmSSB1c2VybmFtZTpwYXNzd29yZFxmUi4gVGhpcyBtaWdodCBzZWVtIGluc2VjdXJlLCBob3dldmVyIGl0IHNob3VsZCBub3QgYmUgd29yc2UgdGhlIGhhdmluZyBhIHVuZW5jcnlwdGVkIHRva2VuIHNhdmVkLgouUkUKCi5JIGJ1aWxkLXN0YXRlLmVuZHBvaW50Ci5SUwpOb3JtYWx5IHRoZSBTdGFzaC9CaXRidWNrZXQgVVJMIGlzIGluZmVycmVkIGZyb20gdGhlIGdpdCByZW1vdGUgc2V0dGluZy4gSFRUUCByZW1vdGVzIGtlZXAgdGhlaXIgcG9ydCBhbmQgYW55IGNvbnRleHQgcGF0aCBiZWZvcmUgXGZJIC9zY20vXGZSLCBmb3IgU1NIIHJlbW90ZXMsIGluY2x1ZGluZyB0aGUgc2NwLWxpa2UgXGZJIGdpdEBleGFtcGxlLmNvbTpwcm9qL3JlcG8uZ2l0XGZSLCBvbmx5IHRoZSBob3N0IGlzIHVzZWQuIFRoaXMgc2V0dGluZyB3aWxsIG92ZXIgcmlkZSB0aGF0LiBXcml0dGVuIG9uIHRoZSBmb3JtIFxmSSBodHRwczovL2V4YW1wbGUuY29tCi5SRQoKLkkgYnVpbGQtc3RhdGUucHJvdmlkZXIKLlJTClRoZSBzZXJ2aWNlIGJ1aWxkIHN0YXRlcyBhcmUgcmVhZCBmcm9tIGFuZCB3cml0dGVuIHRvLiBcZkkgYml0YnVja2V0LXNlcnZlciBcZlIgKGFsc28gXGZJIHN0YXNoXGZSKSB1c2VzIHRoZSBTdGFzaC9CaXRidWNrZXQgU2VydmVyIGJ1aWxkLXN0YXR1cyBBUEksIFxmSSBiaXRidWNrZXQtY2xvdWQgXGZSIHVzZXMgdGhlIEJpdGJ1Y2tldCBDbG91ZCAyLjAgQVBJIHdoZXJlIHRoZSB3b3Jrc3BhY2UgYW5kIHJlcG9zaXRvcnkgYXJlIGRlcml2ZWQgZnJvbSB0aGUgcmVtb3RlLiBcZkkgZ2l0aHViIFxmUiByZWFkcyB0aGUgY29tYmluZWQgY29tbWl0IHN0YXR1cyBhbmQgdGhlIGNoZWNrIHJ1bnMgb2YgR2l0SHViIG9yIEdpdEh1YiBFbnRlcnByaXNlLCBhbmQgc2V0cyBjb21taXQgc3RhdHVzZXMgd2l0aCB0aGUga2V5IGFzIGNvbnRleHQuIFxmSSBnaXRsYWIgXGZSIHJlYWRzIHRoZSBsYXRlc3QgY29tbWl0IHN0YXR1c2VzIG9mIEdpdExhYiwgdGhhdCBpcyB0aGUgam9icyBhbmQgZXh0ZXJuYWwgc3RhdHVzZXMsIG9yIGZvciBjb21taXRzIHdpdGhvdXQgYW55IHRoZSBsYXRlc3QgcGlwZWxpbmUgb2YgZWFjaCByZWYgYW5kIHNvdXJjZSwgd2hlcmUgdGhlIHByb2plY3QgSUQgaXMgdGhlIFVSTCBlbmNvZGVkIHBhdGggb2YgdGhlIHJlbW90ZSwgYW5kIHNldHMgY29tbWl0IHN0YXR1c2VzIHdpdGggdGhlIGtleSBhcyBuYW1lLiBTa2lwcGVkIGFuZCBtYW51YWwgR2l0TGFiIGpvYnMgY291bnQgYXMgc3VjY2Vzc2Z1bCwgam9icyB0aGF0IGFyZSBhbGxvd2VkIHRvIGZhaWwgYXJlIGlnbm9yZWQuIFxmSSBnaXRlYSBcZlIgKGFsc28gXGZJIGZvcmdlam9cZlIpIHJlYWRzIHRoZSBjb21iaW5lZCBjb21taXQgc3RhdHVzIG9mIEdpdGVhIG9yIEZvcmdlam8gYW5kIHNldHMgY29tbWl0IHN0YXR1c2VzIHdpdGggdGhlIGtleSBhcyBjb250ZXh0LCB3YXJuaW5ncyBjb3VudCBhcyBzdWNjZXNzZnVsLgouc3AKRGVmYXVsdHMgdG8gXGZJIGJpdGJ1Y2tldC1jbG91ZCBcZlIgZm9yIHJlbW90ZXMgb24gYml0YnVja2V0Lm9yZywgXGZJIGdpdGh1YiBcZlIgZm9yIGdpdGh1Yi5jb20gYW5kIGhvc3RzIG5hbWVkIGdpdGh1Yi4qLCBcZkkgZ2l0bGFiIFxmUiBmb3IgZ2l0bGFiLmNvbSBhbmQgaG9zdHMgbmFtZWQgZ2l0bGFiLiosIFxmSSBnaXRlYSBcZlIgZm9yIGdpdGVhLmNvbSBhbmQgY29kZWJlcmcub3JnLCBhbmQgXGZJIGJpdGJ1Y2tldC1zZXJ2ZXIgXGZSIG90aGVyd2lzZS4KLnNwCkZvciBCaXRidWNrZXQgQ2xvdWQsIHVzZSBhbiBhcHAgcGFzc3dvcmQgYXMgcGFzc3dvcmQgb3IgYW4gYWNjZXNzIHRva2VuLCBhbmQgXGZJIGJ1aWxkLXN0YXRlLmVuZHBvaW50IFxmUiBkZWZhdWx0cyB0byBodHRwczovL2FwaS5iaXRidWNrZXQub3JnLzIuMC4gRm9yIEdpdEh1YiwgdXNlIGEgdG9rZW4gYW5kIFxmSSBidWlsZC1zdGF0ZS5lbmRwb2ludCBcZlIgZGVmYXVsdHMgdG8gaHR0cHM6Ly9hcGkuZ2l0aHViLmNvbSwgb3IgaHR0cHM6Ly88aG9zdD4vYXBpL3YzIGZvciBHaXRIdWIgRW50ZXJwcmlzZS4gRm9yIEdpdExhYiwgdXNlIGEgdG9rZW4gYW5kIFxmSSBidWlsZC1zdGF0ZS5lbmRwb2ludCBcZlIgZGVmYXVsdHMgdG8gaHR0cHM6Ly88aG9zdD4vYXBpL3Y0LiBGb3IgR2l0ZWEgYW5kIEZvcmdlam8sIHVzZSBhIHRva2VuIGFuZCBcZkkgYnVpbGQtc3RhdGUuZW5kcG9pbnQgXGZSIGRlZmF1bHRzIHRvIGh0dHBzOi8vPGhvc3Q+L2FwaS92MS4KLnNwCkFueSBvdGhlciBuYW1lLCBlLmcuIFxmSSBmb29cZlIsIHJ1bnMgdGhlIGV4dGVybmFsIHByb3ZpZGVyIFxmSSBnaXQtYnVpbGQtc3RhdGUtcHJvdmlkZXItZm9vIFxmUiBmb3VuZCBpbiBQQVRILCBzZWUgUFJPVklERVIgUExVR0lOUy4KLlJFCgouSSBidWlsZC1zdGF0ZS5jb25jdXJyZW5jeQouUlMKTWF4aW11bSBudW1iZXIgb2YgY29uY3VycmVudCByZXF1ZXN0cyB3aGVuIGZldGNoaW5nIGJ1aWxkIHN0YXRpc3RpY3MgZm9yIHRoZSBsb2csIGVpdGhlciBvbmUgcmVxdWVzdCBwZXIgY29tbWl0IHdoZW4gdGhlIHByb3ZpZGVyIGhhcyBubyBiYXRjaCBBUEksIG9yIG9uZSByZXF1ZXN0IHBlciBjaHVuayBvZiBjb21taXRzLCBzZWUgXGZJIGJ1aWxkLXN0YXRlLnN0YXRzLmNodW5rU2l6ZVxmUi4gRGVmYXVsdHMgdG8gNC4KLlJFCgouSSBidWlsZC1zdGF0ZS5zdGF0cy5jaHVua1NpemUKLlJTCk51bWJlciBvZiBjb21taXRzIHBlciBidWlsZCBzdGF0aXN0aWNzIHJlcXVlc3Qgd2l0aCBTdGFzaC9CaXRidWNrZXQgU2VydmVyLiBMYXJnZXIgbG9ncyBhcmUgc3BsaXQgaW50byBzZXZlcmFsIHJlcXVlc3RzIG1hZGUgY29uY3VycmVudGx5LiBJZiBzb21lIG9mIHRoZSByZXF1ZXN0cyBmYWlsLCBhIHdhcm5pbmcgaXMgcHJpbnRlZCBhbmQgdGhlIGxvZyBpcyBzaG93biB3aXRob3V0IHN0YXRpc3RpY3MgZm9yIHRob3NlIGNvbW1pdHMuIERlZmF1bHRzIHRvIDEwMC4KLlJFCgouSSBidWlsZC1zdGF0ZS5jYWNoZS50dGwKLlJTCkhvdyBsb25nIGJ1aWxkIHN0YXRlcyBhcmUgY2FjaGVkLCB3cml0dGVuIGFzIGEgR28gZHVyYXRpb24gc3VjaCBhcyBcZkkgMTJoXGZSLiBCdWlsZCBzdGF0ZXMgYXJlIGNhY2hlZCBvbmNlIGJ1aWxkcyBoYXZlIGJlZW4gcmVwb3J0ZWQgZm9yIHRoZSBjb21taXQgYW5kIG5vbmUgaXMgaW4gcHJvZ3Jlc3MsIHN0YXRlcyBzZXQgd2l0aCBcZkkgLXNldCBcZlIgZHJvcCB0aGUgY2FjaGVkIHN0YXRlIG9mIHRoZSBjb21taXQuIFRoZSBjYWNoZSBpcyBrZXB0IGluIFxmSSAkWERHX0NBQ0hFX0hPTUUvZ2l0LWJ1aWxkLXN0YXRlXGZSLCBvciBcZkkgfi8uY2FjaGUvZ2l0LWJ1aWxkLXN0YXRlXGZSLCB3aXRoIG9uZSBmaWxlIHBlciBjb21taXQgaW4gYSBkaXJlY3RvcnkgcGVyIGhvc3QuIEV4Y2VwdCBmb3IgQml0YnVja2V0IFNlcnZlciwgd2hpY2gga2VlcHMgYnVpbGQgc3RhdGVzIHBlciBjb21taXQsIHRoZSBkaXJlY3RvcnkgYWxzbyBuYW1lcyB0aGUgcmVwb3NpdG9yeSBzbyBmb3JrcyBzaGFyaW5nIGNvbW1pdHMgYXJlIGNhY2hlZCBhcGFydC4gQSBkdXJhdGlvbiBvZiAwIGRpc2FibGVzIHRoZSBjYWNoZS4gRGVmYXVsdHMgdG8gMjRoLgouUkUKCi5JIGJ1aWxkLXN0YXRlLmNhY2hlLmZhaWxlZFRUTAouUlMKSG93IGxvbmcgYnVpbGQgc3RhdGVzIHdpdGggYSBmYWlsZWQgYnVpbGQgYXJlIGNhY2hlZCwgYXMgYSBmYWlsZWQgYnVpbGQgbWF5IGJlIHJldHJpZWQgb24gdGhlIHNhbWUgY29tbWl0LiBJdCBpcyBjYXBwZWQgYnkgXGZJIGJ1aWxkLXN0YXRlLmNhY2hlLnR0bFxmUi4gRGVmYXVsdHMgdG8gNW0uCi5SRQoKLkkgYnVpbGQtc3RhdGUuaHR0cC50aW1lb3V0Ci5SUwpNYXhpbXVtIHRpbWUgZm9yIGEgcmVxdWVzdCB0byB0aGUgc2VydmljZSwgaW5jbHVkaW5nIHJlYWRpbmcgdGhlIHJlc3BvbnNlLCB3cml0dGVuIGFzIGEgR28gZHVyYXRpb24uIDAgbWVhbnMgbm8gdGltZW91dC4gRGVmYXVsdHMgdG8gMzBzLgouUkUKCi5JIGJ1aWxkLXN0YXRlLmh0dHAuY29ubmVjdFRpbWVvdXQKLlJTCk1heGltdW0gdGltZSB0byBlc3RhYmxpc2ggYSBjb25uZWN0aW9uLCBpbmNsdWRpbmcgdGhlIFRMUyBoYW5kc2hha2UuIERlZmF1bHRzIHRvIDEwcy4KLlJFCgouSSBidWlsZC1zdGF0ZS5odHRwLnJldHJpZXMKLlJTCk51bWJlciBvZiB0aW1lcyByZXF1ZXN0cyB0aGF0IG9ubHkgcmVhZCBidWlsZCBzdGF0ZXMgYXJlIHJldHJpZWQgb24gY29ubmVjdGlvbiBlcnJvcnMsIHRpbWVvdXRzIGFuZCBzZXJ2ZXIgZXJyb3JzLCB3aXRoIGV4cG9uZW50aWFsIGJhY2tvZmYgc3RhcnRpbmcgYXQgNTAwbXMgYW5kIHJhbmRvbSBqaXR0ZXIuIFdoZW4gdGhlIHNlcnZlciByZXNwb25kcyB3aXRoIDQyOSBvciA1MDMgYW5kIGEgUmV0cnktQWZ0ZXIgaGVhZGVyLCB0aGUgZGVsYXkgYXNrZWQgZm9yIGlzIHVzZWQsIGRlbGF5cyBvdmVyIGEgbWludXRlIGFyZSBub3Qgd2FpdGVkIGZvci4gU2V0dGluZyB0aGUgYnVpbGQgc3RhdGUgaXMgbmV2ZXIgcmV0cmllZC4gRGVmYXVsdHMgdG8gMy4KLlJFCgouSSBidWlsZC1zdGF0ZS5odHRwLnNzbENBSW5mbywgYnVpbGQtc3RhdGUuaHR0cC5zc2xDZXJ0LCBidWlsZC1zdGF0ZS5odHRwLnNzbEtleSwgYnVpbGQtc3RhdGUuaHR0cC5zc2xWZXJpZnkKLlJTClRMUyBzZXR0aW5ncyBmb3IgdGhlIHNlcnZpY2UsIG92ZXJyaWRpbmcgdGhlIEdJVF9TU0xfQ0FJTkZPLCBHSVRfU1NMX0NFUlQsIEdJVF9TU0xfS0VZIGFuZCBHSVRfU1NMX05PX1ZFUklGWSBlbnZpcm9ubWVudCB2YXJpYWJsZXMsIHdoaWNoIGluIHR1cm4gb3ZlcnJpZGUgZ2l0J3MgXGZJIGh0dHAuc3NsQ0FJbmZvXGZSLCBcZkkgaHR0cC5zc2xDZXJ0XGZSLCBcZkkgaHR0cC5zc2xLZXkgXGZSIGFuZCBcZkkgaHR0cC5zc2xWZXJpZnkgXGZSIHNldHRpbmdzLCBpbmNsdWRpbmcgcGVyIFVSTCBzZXR0aW5ncyBzdWNoIGFzIFxmSSBodHRwLmh0dHBzOi8vZXhhbXBsZS5jb20vLnNzbENBSW5mb1xmUiwgc2VlIGdpdC1jb25maWcoMSkuIFRoZSBDQSBidW5kbGUgcmVwbGFjZXMgdGhlIHN5c3RlbSByb290cy4gVGhlIGNsaWVudCBrZXkgZGVmYXVsdHMgdG8gdGhlIGNlcnRpZmljYXRlIGZpbGUsIGVuY3J5cHRlZCBrZXlzIGFyZSBub3Qgc3VwcG9ydGVkLgouUkUKCi5JIGJ1aWxkLXN0YXRlLmh0dHAucHJveHkKLlJTClByb3h5IGZvciByZXF1ZXN0cyB0byB0aGUgc2VydmljZSwgb3ZlcnJpZGluZyBnaXQncyBcZkkgaHR0cC5wcm94eSBcZlIgYW5kIFxmSSBodHRwLjx1cmw+LnByb3h5IFxmUiBzZXR0aW5ncy4gV3JpdHRlbiBvbiB0aGUgZm9ybSBcZkkgW3Byb3RvY29sOi8vXVt1c2VyWzpwYXNzd29yZF1AXWhvc3RbOnBvcnRdXGZSLCB0aGUgdXNlciBhbmQgcGFzc3dvcmQgYXJlIHVzZWQgZm9yIHByb3h5IGF1dGhlbnRpY2F0aW9uLiBEZWZhdWx0cyB0byB0aGUgSFRUUFNfUFJPWFksIEhUVFBfUFJPWFkgYW5kIE5PX1BST1hZIGVudmlyb25tZW50IHZhcmlhYmxlcy4KLlJFCgouSSBidWlsZC1zdGF0ZS5wbHVnaW4udGltZW91dAouUlMKSG93IGxvbmcgYSBwcm92aWRlciBwbHVnaW4gbWF5IHRha2UgdG8gYW5zd2VyIGEgcmVxdWVzdCwgb3IgdG8gZXhpdCBvbmNlIGl0cyBzdGFuZGFyZCBpbnB1dCBpcyBjbG9zZWQsIGJlZm9yZSBpdCBpcyBraWxsZWQuIFdyaXR0ZW4gYXMgYSBHbyBkdXJhdGlvbiwgMCBtZWFucyBubyB0aW1lb3V0LiBEZWZhdWx0cyB0byBcZkkgYnVpbGQtc3RhdGUuaHR0cC50aW1lb3V0XGZSLgouUkUKCi5JIGJ1aWxkLXN0YXRlLnByb21wdC50aW1lb3V0Ci5SUwpIb3cgbG9uZyBhZnRlciBpdCBzdGFydGVkIFxmSSAtcHJvbXB0IFxmUiBtYXkgd2FpdCBmb3IgdGhlIGJ1aWxkIHN0YXRlIG9mIGEgbmV3IEhFQUQgYmVmb3JlIHByaW50aW5nIG5vdGhpbmcsIGluY2x1ZGluZyB0aGUgdGltZSB0YWtlbiBieSB0aGUgZ2l0IGNvbW1hbmRzIGl0IHJ1bnMuIFRoZSBzdGF0ZSBpcyBrZXB0IGluIHRoZSBjYWNoZSBkaXJlY3RvcnksIHNlZSBcZkkgYnVpbGQtc3RhdGUuY2FjaGUudHRsXGZSLiBEZWZhdWx0cyB0byAxMDBtcy4KLlJFCgouSSBidWlsZC1zdGF0ZS5yZW1vdGUKLlJTClRoZSBnaXQgcmVtb3RlIHRvIHVzZS4gSXQgaXMgYW4gZXJyb3IgaWYgYSByZW1vdGUgZ2l2ZW4gaGVyZSBvciB3aXRoIFxmSSAtcmVtb3RlIFxmUiBkb2VzIG5vdCBleGlzdC4gRGVmYXVsdHMgdG8gdGhlIHVwc3RyZWFtIHJlbW90ZSBvZiB0aGUgY3VycmVudCBicmFuY2gsIHRoZW4gXGZJIG9yaWdpblxmUiwgdGhlbiB0aGUgZmlyc3QgcmVtb3RlLiBUaGUgcHJvamVjdCBrZXkgYW5kIHJlcG9zaXRvcnkgc2x1ZyBhcmUgZGVyaXZlZCBmcm9tIGl0cyBVUkwgYW5kIGF2YWlsYWJsZSBpbiB0ZW1wbGF0ZXMgYXMgXGZJIHt7LlJlcG9zaXRvcnkuUHJvamVjdH19IFxmUiBhbmQgXGZJIHt7LlJlcG9zaXRvcnkuU2x1Z319XGZSLgouUkUKCi5JIGJ1aWxkLXN0YXRlLnBvcnQKLlJTCkRlZmluZXMgdGhlIHBvcnQgZm9yIHRoZSBTdGFzaC9CaXRidWNrZXQgQVBJCi5SRQoKLkkgYnVpbGQtc3RhdGUudXJsLjxiYXNlPi5pbnN0ZWFkT2YKLlJTClJlbW90ZXMgc3RhcnRpbmcgd2l0aCB0aGlzIHZhbHVlIHVzZSBcZkkgYmFzZSBcZlIgYXMgdGhlIFN0YXNoL0JpdGJ1Y2tldCBBUEkgVVJMLCBvciBmb3IgR2l0ZWEgYW5kIEdpdExhYiB0aGUgVVJMIHRoZSBBUEkgcGF0aCwgL2FwaS92MSBhbmQgL2FwaS92NCwgaXMgYXBwZW5kZWQgdG8uIFVzZWZ1bCB3aGVuIHRoZSBTU0ggYW5kIEhUVFAgcG9ydHMgZGlmZmVyLiBUaGUgbG9uZ2VzdCBtYXRjaGluZyB2YWx1ZSB3aW5zLiBFeGFtcGxlOgoubmYKZ2l0IGNvbmZpZyAtLWdsb2JhbCBidWlsZC1zdGF0ZS51cmwuaHR0cHM6Ly9iaXRidWNrZXQuZXhhbXBsZS5jb20uaW5zdGVhZE9mIHNzaDovL2dpdEBiaXRidWNrZXQuZXhhbXBsZS5jb206Nzk5OS8KLmZpCi5SRQoKLkkgYnVpbGQtc3RhdGUucGFnZVNpemUKLlJTCk51bWJlciBvZiBidWlsZCBzdGF0dXNlcyByZXF1ZXN0ZWQgcGVyIHBhZ2UgZnJvbSBTdGFzaC9CaXRidWNrZXQuIERlZmF1bHRzIHRvIHRoZSBzZXJ2ZXIgcGFnZSBzaXplLgouUkUKCi5JIGJ1aWxkLXN0YXRlLnByZXR0eS48bmFtZT4KLlJTCkRlZmluZXMgYSBuYW1lZCBmb3JtYXQsIHNlbGVjdGVkIHdpdGggXGZJIC1mb3JtYXQ9bmFtZTo8bmFtZT4gXGZSIG9yIGFzIHRoZSB2YWx1ZSBvZiBcZkkgYnVpbGQtc3RhdGUuZm9ybWF0LmxvZ1xmUiwgXGZJIGJ1aWxkLXN0YXRlLmZvcm1hdC5zdGF0ZSBcZlIgYW5kIFxmSSBidWlsZC1zdGF0ZS5mb3JtYXQucHJvbXB0XGZSLiBUaGUgdmFsdWUgaXMgYSB0ZW1wbGF0ZSwgb3IgXGZJIEA8ZmlsZT4gXGZSIHRvIHJlYWQgdGhlIHRlbXBsYXRlIGZyb20gYSBmaWxlLCB3aGljaCBsZXRzIGxvbmcgdGVtcGxhdGVzIGJlIHNoYXJlZCBpbiBhIHJlcG9zaXRvcnkuIEFzIHdpdGggZ2l0J3MgcHJldHR5IGZvcm1hdHMsIGJ1aWx0LWluIG5hbWVzIGNhbiBub3QgYmUgcmVkZWZpbmVkLgouc3AKVGhlIGJ1aWx0LWluIGZvcm1hdHMgZGVwZW5kIG9uIHRoZSB2aWV3LiBGb3IgdGhlIGxvZywgXGZJIG9uZWxpbmUgXGZSIHNob3dzIHRoZSBhYmJyZXZpYXRlZCBjb21taXQsIGEgYmFkZ2Ugb2YgdGhlIGJ1aWxkIHN0YXRzIGFuZCB0aGUgc3ViamVjdCwgXGZJIHNob3J0IFxmUiBpcyB0aGUgcGxhaW4gZGVmYXVsdCwgXGZJIGZ1bGwgXGZSIHNob3dzIHRoZSBmdWxsIGNvbW1pdCB3aXRoIHRoZSBiYWRnZSBiZWxvdyB0aGUgc3ViamVjdCBhbmQgXGZJIHByb21wdCBcZlIgc2hvd3MgdGhlIHN0YXRlcyB3aXRoIGJ1aWxkcyBhcyB3aXRoIFxmSSAtcHJvbXB0XGZSLiBGb3IgdGhlIGJ1aWxkIHN0YXRlLCBcZkkgb25lbGluZSBcZlIgc2hvd3MgdGhlIGdseXBoLCBzdGF0ZSwga2V5IGFuZCBuYW1lIG9mIGVhY2ggYnVpbGQgb24gb25lIGxpbmUsIFxmSSBzaG9ydCBcZlIgdGhlIHN0YXRlLCBuYW1lIGFuZCBVUkwsIFxmSSBmdWxsIFxmUiBhZGRzIHRoZSBjb21taXQsIGRhdGUgYW5kIGRlc2NyaXB0aW9uLCBhbmQgXGZJIHByb21wdCBcZlIgdGhlIGdseXBoIGFuZCBrZXkuCi5SRQoKLkkgYnVpbGQtc3RhdGUuZm9ybWF0LnByb21wdAouUlMKVGVtcGxhdGUgZGVmaW5pdGlvbiBvZiB0aGUgb3V0cHV0IG9mIFxmSSAtcHJvbXB0XGZSLCBleGVjdXRlZCB3aXRoIHRoZSBzYW1lIGRhdGEgYXMgdGhlIGxvZyB0ZW1wbGF0ZS4gRGVmYXVsdHMgdG8gdGhlIGJ1aWx0LWluIFxmSSBwcm9tcHQgXGZSIGZvcm1hdC4KLlJFCgouSSBidWlsZC1zdGF0ZS5mb3JtYXQubG9nCi5SUwpUZW1wbGF0ZSBkZWZpbml0aW9uIG9mIHRoZSBvdXRwdXQgZm9yIHRoZSBsb2cuIFRoZSBkZWZhdWx0IHRlbXBsYXRlIGRlZmluaXRpb246Ci5uZgp7ey5JRH19IHt7Lk1lc3NhZ2V9fQogICBTdWNjZXNzZnVsOiB7ey5TdGF0dXMuU3VjY2Vzc2Z1bH19LAogICBJbiBQcm9ncmVzczoge3suU3RhdHVzLkluUHJvZ3Jlc3N9fSwKICAgRmFpbGVkOiB7ey5TdGF0dXMuRmFpbGVkfX0KLmZpCi5zcApXaGVuIGNvbG91ciBpcyBlbmFibGVkIHRoZSBkZWZhdWx0IGNvbG91cnMgdGhlIHN0YXRlIGNvdW50cyBhbmQgbGlua3MgdGhlIGNvbW1pdCBJRCB0byBpdHMgd2ViIHBhZ2UsIFxmSSAuQ29tbWl0VVJMXGZSLCB3aGVuIGtub3duIGJ5IHRoZSBwcm92aWRlci4KLlJFCgouSSBidWlsZC1zdGF0ZS5mb3JtYXQuc3RhdGUKLlJTClRlbXBsYXRlIGRlZmluaXRpb24gb2YgdGhlIG91dHB1dCBmb3IgdGhlIGJ1aWxkIHN0YXRlLiBUaGUgZGVmYXVsdCB0ZW1wbGF0ZSBkZWZpbml0aW9uOgoKLm5mCk5hbWU6ICB7ey5OYW1lfX0gICAgIEtleToge3suS2V5fX0KU3RhdGU6IHt7LlN0YXRlfX0KVVJMOiAgIHt7LlVSTH19CkRhdGU6ICB7ey5EYXRlQWRkZWR9fQoKICAge3suRGVzY3JpcHRpb259fQouZmkKLnNwCldoZW4gY29sb3VyIGlzIGVuYWJsZWQgdGhlIGRlZmF1bHQgY29sb3VycyB0aGUgc3RhdGUgYW5kIG1ha2VzIHRoZSBVUkwgYSBoeXBlcmxpbmsuIFRoZSBjb21taXQgYW5kIGl0cyB3ZWIgcGFnZSBhcmUgYXZhaWxhYmxlIGFzIFxmSSAuQ29tbWl0IFxmUiBhbmQgXGZJIC5Db21taXRVUkxcZlIuCi5SRQoKLkkgY29sb3IuYnVpbGQtc3RhdGUKLlJTCldoZXRoZXIgdG8gY29sb3VyIHRoZSBvdXRwdXQsIHNlZSBcZkkgLWNvbG9yIFxmUiBhbmQgZ2l0LWNvbmZpZygxKS4gRGVmYXVsdHMgdG8gXGZJIGNvbG9yLnVpXGZSLgouUkUKCi5JIFRlbXBsYXRlIGZ1bmN0aW9ucwouUlMKQm90aCB0ZW1wbGF0ZXMgbWF5IHVzZSB0aGUgZm9sbG93aW5nIGZ1bmN0aW9ucy4gVGhlIHZhbHVlIG9wZXJhdGVkIG9uIGlzIHRoZSBsYXN0IGFyZ3VtZW50LCBzbyBmdW5jdGlvbnMgY2FuIGJlIHVzZWQgaW4gcGlwZWxpbmVzLCBlLmcuIFxmSSB7ey5OYW1lIHwgcGFkIDIwfX1cZlIuCi5zcApcZkIgYWJicmV2IFs8bGVuZ3RoPl0gPGNvbW1pdD5cZlIKLlJTCkFiYnJldmlhdGVzIHRoZSBjb21taXQsIG9yIGFueSB0ZXh0LCB0byA3IGNoYXJhY3RlcnMgb3IgdGhlIGxlbmd0aCBnaXZlbi4KLlJFCi5zcApcZkIgcGFkIDx3aWR0aD4gPHRleHQ+XGZSCi5SUwpQYWRzIHRoZSB0ZXh0IHdpdGggc3BhY2VzIHRvIHRoZSB3aWR0aCwgbmVnYXRpdmUgd2lkdGhzIHBhZCBvbiB0aGUgbGVmdC4gUGFkIGJlZm9yZSBjb2xvdXJpbmcsIGFzIGVzY2FwZSBzZXF1ZW5jZXMgY291bnQgaW4gdGhlIHdpZHRoLgouUkUKLnNwClxmQiB0cnVuY2F0ZSA8bGVuZ3RoPiA8dGV4dD5cZlIKLlJTClNob3J0ZW5zIHRoZSB0ZXh0IHRvIHRoZSBsZW5ndGgsIGVuZGluZyB3aXRoIFwodTIwMjYgd2hlbiBzaG9ydGVuZWQuCi5SRQouc3AKXGZCIGNvbG9yIDxzdGF0ZXxuYW1lPiA8dGV4dD4uLi5cZlIKLlJTCkNvbG91cnMgdGhlIHRleHQgYnkgYSBidWlsZCBzdGF0ZSwgb3IgYnkgY29sb3VyIG5hbWVzIHNlcGFyYXRlZCBieSBzcGFjZTogYm9sZCwgZGltLCByZWQsIGdyZWVuLCB5ZWxsb3csIGJsdWUsIG1hZ2VudGEgYW5kIGN5YW4uIE9ubHkgd2hlbiBjb2xvdXIgaXMgZW5hYmxlZC4KLlJFCi5zcApcZkIgZ2x5cGggPHN0YXRlPlxmUgouUlMKVGhlIGdseXBoIG9mIHRoZSBidWlsZCBzdGF0ZTogXCh1MjcxNCBmb3IgU1VDQ0VTU0ZVTCwgXCh1MjVDRiBmb3IgSU5QUk9HUkVTUyBhbmQgXCh1MjcxOCBmb3IgRkFJTEVELgouUkUKLnNwClxmQiBsaW5rIDx1cmw+IDx0ZXh0Pi4uLlxmUgouUlMKTWFrZXMgdGhlIHRleHQgYW4gT1NDIDggaHlwZXJsaW5rIHRvIHRoZSBVUkwuIE9ubHkgd2hlbiBjb2xvdXIgaXMgZW5hYmxlZC4KLlJFCi5zcApcZkIgc2luY2UgPHRpbWU+XGZSCi5SUwpUaGUgdGltZSByZWxhdGl2ZSB0byBub3csIGUuZy4gXGZJIHt7c2luY2UgLkRhdGVBZGRlZH19IFxmUiBnaXZlcyAzIGhvdXJzIGFnby4KLlJFCi5zcApcZkIgZGF0ZSA8bGF5b3V0PiA8dGltZT5cZlIKLlJTCkZvcm1hdHMgdGhlIHRpbWUgaW4gbG9jYWwgdGltZSB3aXRoIGEgR28gbGF5b3V0LCBlLmcuIFxmSSB7e2RhdGUgIjIwMDYtMDEtMDIgMTU6MDQiIC5EYXRlQWRkZWR9fVxmUi4KLlJFCi5zcApcZkIgdXBwZXIgPHRleHQ+XGZSLCBcZkIgbG93ZXIgPHRleHQ+XGZSCi5SUwpDb252ZXJ0cyB0aGUgdGV4dCB0byB1cHBlciBvciBsb3dlciBjYXNlLgouUkUKLnNwClxmQiBqc29uIDx2YWx1ZT5cZlIKLlJTCkVuY29kZXMgdGhlIHZhbHVlIGFzIEpTT04sIGUuZy4gXGZJIHt7anNvbiAuU3RhdHVzfX1cZlIuCi5SRQouc3AKXGZCIGpvaW4gPHNlcGFyYXRvcj4gPGxpc3Q+XGZSCi5SUwpKb2lucyB0aGUgZWxlbWVudHMgb2YgdGhlIGxpc3Qgd2l0aCB0aGUgc2VwYXJhdG9yLgouUkUKLnNwClxmQiBkZWZhdWx0IDxkZWZhdWx0PiA8dmFsdWU+XGZSCi5SUwpUaGUgZGVmYXVsdCBmb3IgZW1wdHkgdmFsdWVzLCBlLmcuIFxmSSB7e2RlZmF1bHQgIi0iIC5EZXNjcmlwdGlvbn19XGZSLgouUkUKLlJFCi5cLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLSBQUk9WSURFUiBQTFVHSU5TIC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tCi5TSCBQUk9WSURFUiBQTFVHSU5TClNlcnZpY2VzIHdpdGhvdXQgYSBidWlsdC1pbiBwcm92aWRlciBhcmUgc3VwcG9ydGVkIGJ5IGV4dGVybmFsIGV4ZWN1dGFibGVzIG5hbWVkIFxmSSBnaXQtYnVpbGQtc3RhdGUtcHJvdmlkZXItPG5hbWU+XGZSLCBzZWxlY3RlZCB3aXRoIFxmSSBidWlsZC1zdGF0ZS5wcm92aWRlclxmUi4gTGlrZSBnaXQgcmVtb3RlIGhlbHBlcnMsIHRoZSBwbHVnaW4gaXMgc3RhcnRlZCBvbmNlIHBlciBpbnZvY2F0aW9uIGFuZCBzZW50IG9uZSBKU09OIHJlcXVlc3QgcGVyIGxpbmUgb24gaXRzIHN0YW5kYXJkIGlucHV0LCBpdCBtdXN0IGFuc3dlciBlYWNoIHJlcXVlc3Qgd2l0aCBvbmUgSlNPTiB2YWx1ZSBvbiBpdHMgc3RhbmRhcmQgb3V0cHV0IGFuZCBleGl0IHdoZW4gaXRzIHN0YW5kYXJkIGlucHV0IGlzIGNsb3NlZC4gQSBwbHVnaW4gbm90IGFuc3dlcmluZyB3aXRoaW4gXGZJIGJ1aWxkLXN0YXRlLnBsdWdpbi50aW1lb3V0IFxmUiBpcyBraWxsZWQuIFN0YW5kYXJkIGVycm9yIGlzIHBhc3NlZCB0aHJvdWdoLgouc3AKQWxsIHJlcXVlc3RzIGNhcnJ5IFxmSSBvcCBcZlIgYW5kIFxmSSByZXBvc2l0b3J5XGZSLCB0aGUgcmVtb3RlLCBob3N0LCBwcm9qZWN0IGFuZCBzbHVnIG9mIHRoZSByZXBvc2l0b3J5Lgouc3AKLlJTClxmQnsib3AiOiJzdGF0dXMiLCJjb21taXQiOiI8c2hhPiIsLi4ufVxmUgouUlMKQW5zd2VyZWQgd2l0aCB0aGUgYnVpbGQgc3RhdHVzZXMgb2YgdGhlIGNvbW1pdCwgYXMgU3Rhc2gvQml0YnVja2V0IGRvZXM6IFxmSSB7InZhbHVlcyI6W3sic3RhdGUiOiJTVUNDRVNTRlVMIiwia2V5IjoiLi4uIiwibmFtZSI6Ii4uLiIsInVybCI6Ii4uLiIsImRlc2NyaXB0aW9uIjoiLi4uIiwiZGF0ZUFkZGVkIjoxNjAwMDAwMDAwMDAwfV19XGZSLCB3aGVyZSB0aGUgc3RhdGUgaXMgb25lIG9mIFNVQ0NFU1NGVUwsIElOUFJPR1JFU1MgYW5kIEZBSUxFRC4KLlJFCi5zcApcZkJ7Im9wIjoic3RhdHMiLCJjb21taXRzIjpbIjxzaGE+IiwuLi5dLC4uLn1cZlIKLlJTCkFuc3dlcmVkIHdpdGggdGhlIGJ1aWxkIHN0YXRpc3RpY3Mgb2YgZWFjaCBjb21taXQ6IFxmSSB7IjxzaGE+Ijp7InN1Y2Nlc3NmdWwiOjEsImluUHJvZ3Jlc3MiOjAsImZhaWxlZCI6MH19XGZSLgouUkUKLnNwClxmQnsib3AiOiJzZXQiLCJjb21taXQiOiI8c2hhPiIsInN0YXR1cyI6ey4uLn0sLi4ufVxmUgouUlMKU2V0cyB0aGUgYnVpbGQgc3RhdHVzLCBvbiB0aGUgc2FtZSBmb3JtIGFzIHRoZSB2YWx1ZXMgYWJvdmUsIGZvciB0aGUgY29tbWl0LiBBbnN3ZXJlZCB3aXRoIFxmSSB7fVxmUi4KLlJFCi5SRQouc3AKRmFpbHVyZXMgYXJlIGFuc3dlcmVkIHdpdGggXGZJIHsiZXJyb3JzIjpbeyJtZXNzYWdlIjoiLi4uIn1dfVxmUiwgdGhlIG1lc3NhZ2VzIGFyZSByZXBvcnRlZCBieSBnaXQtYnVpbGQtc3RhdGUuCgouXC0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tIEFVVEhPUiAtLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLS0tLQouU0ggQVVUSE9SCk5pbHMgTGFnZXJrdmlzdCA8bmlscyBkb3QgbGFnZXJrdmlzdCBhdCBnbWFpbCBkb3QgY29tPgo=",
 	}
 	res, _ := base64.StdEncoding.DecodeString(a[key])
 	return res
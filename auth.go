@@ -0,0 +1,298 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Authentication types for build-state.auth.type
+const (
+	authTypeBasic      = "basic"
+	authTypeToken      = "token"
+	authTypeBearer     = "bearer"
+	authTypeCredential = "credential"
+)
+
+// authType returns the configured authentication type, token and bearer are
+// synonyms
+func authType() string {
+	switch t := strings.ToLower(defaultGitConfig("build-state.auth.type")); t {
+	case "":
+		return authTypeBasic
+	case authTypeBearer:
+		return authTypeToken
+	default:
+		return t
+	}
+}
+
+// newAuthenticator finds credentials for the API at URL. Environment
+// variables take precedence over .netrc, which takes precedence over the
+// configuration selected by build-state.auth.type. Requests are sent
+// unauthenticated when no credentials are found. The build-state.auth.*
+// settings without a URL are only used if unscoped is set, they are written
+// by -install for Stash/Bitbucket and must not be sent to other servers.
+func newAuthenticator(URL *url.URL, unscoped bool) (Authenticator, error) {
+	cfg := authConfig{url: URL, unscoped: unscoped}
+
+	a, source, err := authenticatorFromEnv(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if a != nil {
+		debug.Printf("Authentication: %s", source)
+		return a, nil
+	}
+
+	if entry, ok := netrcLookup(URL.Hostname()); ok && entry.password != "" {
+		debug.Printf("Authentication: %s (machine %q)", netrcPath(), entry.machine)
+		return newBasicAuth(entry.login, entry.password), nil
+	}
+
+	// The type is no secret, the one without a URL applies to all services.
+	// Only missing credentials of a type set for the service are an error.
+	typeCfg := cfg
+	typeCfg.unscoped = true
+	t, typeKey, err := typeCfg.get("type")
+	if err != nil {
+		return nil, err
+	}
+	configured := t != "" && (unscoped || typeKey != "build-state.auth.type")
+	switch t = strings.ToLower(t); t {
+	case "", authTypeBasic:
+		user, _, err := cfg.get("user")
+		if err != nil {
+			return nil, err
+		}
+		credentials, key, err := cfg.get("credentials")
+		if err != nil {
+			return nil, err
+		}
+		if credentials != "" {
+			debug.Printf("Authentication: git config %s", key)
+			return newBasicAuthFromCredentials(user, credentials), nil
+		}
+		if configured {
+			return nil, fmt.Errorf("build-state.auth.credentials is not set for %s, run with -install or -generate-creds", URL)
+		}
+	case authTypeToken, authTypeBearer:
+		token, key, err := cfg.get("token")
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			debug.Printf("Authentication: git config %s", key)
+			return newTokenAuth(token, key), nil
+		}
+		if configured {
+			return nil, fmt.Errorf("build-state.auth.token is not set for %s, run with -install or -generate-creds", URL)
+		}
+	case authTypeCredential:
+		debug.Printf("Authentication: git credential helpers")
+		return newCredentialAuth(URL)
+	default:
+		return nil, fmt.Errorf("unknown build-state.auth.type: %q, expected %s, %s, %s or %s", t, authTypeBasic, authTypeToken, authTypeBearer, authTypeCredential)
+	}
+
+	debug.Printf("Authentication: no credentials found, sending unauthenticated requests")
+	return anonymousAuth{}, nil
+}
+
+// authConfig reads the build-state.auth.* settings for the API at url.
+// Settings for a URL, build-state.<url>.auth.<name>, take precedence and the
+// most specific URL wins, as for http.<url>.* in git-config(1).
+type authConfig struct {
+	url      *url.URL
+	unscoped bool
+}
+
+// get returns the value of the setting and its key, an empty string is
+// returned if it is not set
+func (c authConfig) get(name string) (value, key string, err error) {
+	vars, err := gitConfigRegexp(`^build-state\..+\.auth\.` + name + `$`)
+	if err != nil {
+		return "", "", err
+	}
+
+	best := -1
+	for _, v := range vars {
+		scope := strings.TrimSuffix(strings.TrimPrefix(v[0], "build-state."), ".auth."+name)
+		if n := urlMatch(scope, c.url); n > best {
+			best, value, key = n, v[1], v[0]
+		}
+	}
+	if best >= 0 || !c.unscoped {
+		return value, key, nil
+	}
+
+	key = "build-state.auth." + name
+	return defaultGitConfig(key), key, nil
+}
+
+// urlMatch tells how specific pattern matches u, the length of its path, or -1
+// if it does not match. The scheme may be left out, the path matches at path
+// segments.
+func urlMatch(pattern string, u *url.URL) int {
+	if !strings.Contains(pattern, "://") {
+		pattern = "//" + pattern
+	}
+	p, err := url.Parse(pattern)
+	if err != nil || p.Host == "" {
+		return -1
+	}
+	if p.Scheme != "" && !strings.EqualFold(p.Scheme, u.Scheme) {
+		return -1
+	}
+	if !strings.EqualFold(p.Host, u.Host) {
+		return -1
+	}
+	prefix := strings.TrimSuffix(p.Path, "/")
+	if prefix != "" && u.Path != prefix && !strings.HasPrefix(u.Path, prefix+"/") {
+		return -1
+	}
+	return len(prefix)
+}
+
+// authenticatorFromEnv creates an authenticator from GIT_BUILD_STATE_TOKEN, or
+// from GIT_BUILD_STATE_USER and GIT_BUILD_STATE_PASSWORD
+func authenticatorFromEnv(cfg authConfig) (Authenticator, string, error) {
+	if token := os.Getenv("GIT_BUILD_STATE_TOKEN"); token != "" {
+		return newTokenAuth(token, "GIT_BUILD_STATE_TOKEN"), "environment GIT_BUILD_STATE_TOKEN", nil
+	}
+
+	user, password := os.Getenv("GIT_BUILD_STATE_USER"), os.Getenv("GIT_BUILD_STATE_PASSWORD")
+	if password == "" {
+		return nil, "", nil
+	}
+	if user == "" {
+		var err error
+		if user, _, err = cfg.get("user"); err != nil {
+			return nil, "", err
+		}
+	}
+	return newBasicAuth(user, password), "environment GIT_BUILD_STATE_USER and GIT_BUILD_STATE_PASSWORD", nil
+}
+
+// anonymousAuth sends requests without credentials
+type anonymousAuth struct{}
+
+// Auth leaves the request as is
+func (anonymousAuth) Auth(r *http.Request) *http.Request {
+	return r
+}
+
+// Rejected explains that credentials are missing
+func (anonymousAuth) Rejected(err error) error {
+	return fmt.Errorf("authentication is required, run with -install or see CONFIGURATION in git-build-state(1): %v", err)
+}
+
+// readAuthType asks for the authentication type, defaulting to the
+// configured one
+func readAuthType() string {
+	current := authType()
+	for {
+		t := strings.ToLower(readLine(fmt.Sprintf("Authentication type (%s, %s, %s) [%s]: ", authTypeBasic, authTypeToken, authTypeCredential, current)))
+		switch t {
+		case "":
+			return current
+		case authTypeBasic, authTypeToken, authTypeCredential:
+			return t
+		case authTypeBearer:
+			return authTypeToken
+		}
+		fmt.Printf("Unknown authentication type: %s\n", t)
+	}
+}
+
+func readToken() string {
+	fmt.Print("Access token: ")
+	token, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	logFatalOnError(err)
+
+	// Print a new line as the we did not echo when reading the token
+	fmt.Println("")
+
+	return strings.TrimSpace(string(token))
+}
+
+// CredentialAuth obtains username and password through git credential
+// helpers, see gitcredentials(7)
+type CredentialAuth struct {
+	mu          sync.Mutex
+	url         *url.URL
+	credentials []byte
+	user        string
+	password    string
+	approved    bool
+}
+
+// newCredentialAuth has git fill the credentials for the URL, which may ask
+// for them on the terminal
+func newCredentialAuth(URL *url.URL) (*CredentialAuth, error) {
+	output, err := gitCredential("fill", []byte("url="+URL.String()+"\n\n"))
+	if err != nil {
+		return nil, fmt.Errorf("git credential fill failed for %s: %v", URL, err)
+	}
+	user, password := parseCredentials(output)
+	debug.Printf("Git credential helper provided credentials for user %s", user)
+
+	return &CredentialAuth{
+		url:         URL,
+		credentials: output,
+		user:        user,
+		password:    password,
+	}, nil
+}
+
+// Auth will add authentication headers to the request object
+func (ca *CredentialAuth) Auth(r *http.Request) *http.Request {
+	r.SetBasicAuth(ca.user, ca.password)
+	return r
+}
+
+// Approved tells the credential helpers to store the credentials
+func (ca *CredentialAuth) Approved() {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	if ca.approved {
+		return
+	}
+	ca.approved = true
+	if _, err := gitCredential("approve", ca.credentials); err != nil {
+		debug.Printf("Unable to approve git credentials: %v", err)
+	}
+}
+
+// Rejected tells the credential helpers to forget the credentials
+func (ca *CredentialAuth) Rejected(err error) error {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	if _, rerr := gitCredential("reject", ca.credentials); rerr != nil {
+		debug.Printf("Unable to reject git credentials: %v", rerr)
+	}
+	return fmt.Errorf("the credentials from the git credential helper were rejected and have been removed, new credentials will be asked for next time: %v", err)
+}
+
+func parseCredentials(b []byte) (user, password string) {
+	for _, line := range strings.Split(string(b), "\n") {
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch parts[0] {
+		case "username":
+			user = parts[1]
+		case "password":
+			password = parts[1]
+		}
+	}
+	return user, password
+}
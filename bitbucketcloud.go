@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const bitbucketCloudDefaultAPIURL = "https://api.bitbucket.org/2.0"
+
+// BitbucketCloudService implements the Provider interface for Bitbucket Cloud
+type BitbucketCloudService struct {
+	url           *url.URL
+	authenticator Authenticator
+	repository    Repository
+	listOptions
+}
+
+func newBitbucketCloudService(URL *url.URL, a Authenticator, repo Repository, opts listOptions) *BitbucketCloudService {
+	return &BitbucketCloudService{
+		url:           URL,
+		authenticator: a,
+		repository:    repo,
+		listOptions:   opts,
+	}
+}
+
+// bitbucketCloudAPIURL returns build-state.endpoint, or the public Bitbucket
+// Cloud API
+func bitbucketCloudAPIURL() (*url.URL, error) {
+	if endpoint := defaultGitConfig("build-state.endpoint"); endpoint != "" {
+		return url.Parse(endpoint)
+	}
+	return url.Parse(bitbucketCloudDefaultAPIURL)
+}
+
+func (s *BitbucketCloudService) commitURL(c CommitID) (string, error) {
+	if s.repository.Project == "" || s.repository.Slug == "" {
+		return "", fmt.Errorf("unable to derive the Bitbucket Cloud workspace and repository from the git remote")
+	}
+	return fmt.Sprintf("%s/repositories/%s/%s/commit/%s", s.url, url.PathEscape(s.repository.Project), url.PathEscape(s.repository.Slug), c), nil
+}
+
+// CommitURL returns the web page of the commit
+func (s *BitbucketCloudService) CommitURL(c CommitID) string {
+	if s.repository.Project == "" || s.repository.Slug == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/commits/%s", url.PathEscape(s.repository.Project), url.PathEscape(s.repository.Slug), c)
+}
+
+func (s *BitbucketCloudService) do(req *http.Request) ([]byte, error) {
+	_, body, err := doRequest(s.authenticator, req, bitbucketCloudAPIError)
+	return body, err
+}
+
+// BuildStatus provides detailed information regarding the build. All pages
+// are fetched and merged into one response.
+func (s *BitbucketCloudService) BuildStatus(c CommitID) (BuildStatusResponse, error) {
+	commitURL, err := s.commitURL(c)
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	var values []BuildStatus
+	err = getPages(s.authenticator, bitbucketCloudAPIError, commitURL+"/statuses", s.pageSize, "pagelen", nil, func(body []byte) (string, error) {
+		var page struct {
+			Next   string `json:"next"`
+			Values []struct {
+				State       BuildState `json:"state"`
+				Key         string     `json:"key"`
+				Name        string     `json:"name"`
+				URL         string     `json:"url"`
+				Description string     `json:"description"`
+				UpdatedOn   StashTime  `json:"updated_on"`
+			} `json:"values"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return "", err
+		}
+
+		for _, v := range page.Values {
+			// Stopped builds will not complete
+			if v.State == "STOPPED" {
+				v.State = BuildStateFailed
+			}
+			values = append(values, BuildStatus{
+				State:       v.State,
+				Key:         v.Key,
+				Name:        v.Name,
+				URL:         v.URL,
+				Description: v.Description,
+				DateAdded:   v.UpdatedOn,
+			})
+		}
+		return page.Next, nil
+	})
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	return newBuildStatusResponse(values), nil
+}
+
+// BuildStats lists status given commit ids. Bitbucket Cloud has no batch API,
+// the statuses of each commit are fetched.
+func (s *BitbucketCloudService) BuildStats(c CommitIDer) (BuildStatusCommitStats, error) {
+	return collectBuildStats(c, s.concurrency, s.BuildStatus)
+}
+
+// SetBuildStatus associates a build status with the commit
+func (s *BitbucketCloudService) SetBuildStatus(c CommitID, bs BuildStatus) error {
+	commitURL, err := s.commitURL(c)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(struct {
+		State       BuildState `json:"state"`
+		Key         string     `json:"key"`
+		Name        string     `json:"name,omitempty"`
+		URL         string     `json:"url"`
+		Description string     `json:"description,omitempty"`
+	}{bs.State, bs.Key, bs.Name, bs.URL, bs.Description})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", commitURL+"/statuses/build", bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = s.do(req)
+	return err
+}
+
+// BitbucketCloudError is used for unmarshaling JSON errors from Bitbucket
+// Cloud
+type BitbucketCloudError struct {
+	Err struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	} `json:"error"`
+}
+
+// bitbucketCloudAPIError is the apiErrorFunc for Bitbucket Cloud
+func bitbucketCloudAPIError(b []byte) error {
+	var e BitbucketCloudError
+	if err := json.Unmarshal(b, &e); err != nil || e.Err.Message == "" {
+		return nil
+	}
+	return e
+}
+
+func (e BitbucketCloudError) Error() string {
+	if e.Err.Detail == "" {
+		return e.Err.Message
+	}
+	return e.Err.Message + ": " + e.Err.Detail
+}
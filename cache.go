@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultCacheTTL is how long terminal build states are cached
+	defaultCacheTTL = 24 * time.Hour
+
+	// defaultCacheFailedTTL is how long failed build states are cached, a
+	// failed build may be retried and turn green on the same commit
+	defaultCacheFailedTTL = 5 * time.Minute
+)
+
+// cacheDir returns the directory of the git-build-state cache,
+// $XDG_CACHE_HOME/git-build-state or ~/.cache/git-build-state
+func cacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "git-build-state")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cache", "git-build-state")
+}
+
+// cacheEntry is the content of a cache file
+type cacheEntry struct {
+	Cached time.Time              `json:"cached"`
+	Status *BuildStatusResponse   `json:"status,omitempty"`
+	Stat   *BuildStatusCommitStat `json:"stat,omitempty"`
+}
+
+// failed reports whether a build of the cached state failed
+func (e cacheEntry) failed() bool {
+	if e.Status != nil {
+		return e.Status.Stat().Failed > 0
+	}
+	return e.Stat != nil && e.Stat.Failed > 0
+}
+
+// cachedProvider wraps a provider and caches build states once no build is in
+// progress. The cache is keyed by the key of the provider and commit, one file
+// per commit.
+type cachedProvider struct {
+	Provider
+	dir       string
+	ttl       time.Duration
+	failedTTL time.Duration
+
+	// refresh skips the lookup, results are still stored
+	refresh bool
+}
+
+// newCachedProvider caches the build states of p in a directory named by key,
+// which is the host optionally followed by the repository path
+func newCachedProvider(p Provider, key string, ttl, failedTTL time.Duration, refresh bool) Provider {
+	dir := cacheDir()
+	if dir == "" || key == "" || ttl <= 0 {
+		debug.Printf("Cache disabled")
+		return p
+	}
+	dir = filepath.Join(dir, filepath.FromSlash(strings.Replace(key, ":", "_", -1)))
+	if failedTTL > ttl {
+		failedTTL = ttl
+	}
+	debug.Printf("Cache: %s, TTL: %s, failed TTL: %s", dir, ttl, failedTTL)
+
+	return &cachedProvider{
+		Provider:  p,
+		dir:       dir,
+		ttl:       ttl,
+		failedTTL: failedTTL,
+		refresh:   refresh,
+	}
+}
+
+func (c *cachedProvider) path(commit CommitID, kind string) string {
+	return filepath.Join(c.dir, string(commit)+"."+kind+".json")
+}
+
+// load reads the cache entry, missing, unreadable and expired entries are
+// reported as not found. Failed entries expire after the failed TTL.
+func (c *cachedProvider) load(commit CommitID, kind string) (cacheEntry, bool) {
+	var entry cacheEntry
+	if c.refresh {
+		return entry, false
+	}
+
+	b, err := ioutil.ReadFile(c.path(commit, kind))
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(b, &entry); err != nil {
+		debug.Printf("Ignoring cache entry for %s: %v", commit, err)
+		return entry, false
+	}
+	ttl := c.ttl
+	if entry.failed() {
+		ttl = c.failedTTL
+	}
+	if time.Since(entry.Cached) > ttl {
+		return entry, false
+	}
+	debug.Printf("Cached %s for %s", kind, commit)
+	return entry, true
+}
+
+// store writes the cache entry to a temporary file that is renamed in place,
+// so concurrent invocations never read a partial entry. Failures are only
+// logged as debug output, the cache is an optimization.
+func (c *cachedProvider) store(commit CommitID, kind string, entry cacheEntry) {
+	entry.Cached = time.Now()
+	b, err := json.Marshal(entry)
+	if err != nil {
+		debug.Printf("Unable to cache %s for %s: %v", kind, commit, err)
+		return
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		debug.Printf("Unable to cache %s for %s: %v", kind, commit, err)
+		return
+	}
+
+	f, err := ioutil.TempFile(c.dir, string(commit)+".tmp")
+	if err != nil {
+		debug.Printf("Unable to cache %s for %s: %v", kind, commit, err)
+		return
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(commit, kind))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		debug.Printf("Unable to cache %s for %s: %v", kind, commit, err)
+	}
+}
+
+// BuildStatus returns the cached build statuses of the commit, or fetches
+// them and caches them if no build is in progress
+func (c *cachedProvider) BuildStatus(commit CommitID) (BuildStatusResponse, error) {
+	if entry, ok := c.load(commit, "status"); ok && entry.Status != nil {
+		return *entry.Status, nil
+	}
+
+	bs, err := c.Provider.BuildStatus(commit)
+	if err != nil {
+		return bs, err
+	}
+	if stat := bs.Stat(); stat.terminal() {
+		c.store(commit, "status", cacheEntry{Status: &bs})
+	}
+	return bs, nil
+}
+
+// BuildStats returns the cached build statistics and fetches the ones
+// missing, which are cached if no build is in progress
+func (c *cachedProvider) BuildStats(ci CommitIDer) (BuildStatusCommitStats, error) {
+	stats := BuildStatusCommitStats{}
+	var missing CommitIDs
+	for _, commit := range ci.CommitIDs() {
+		if entry, ok := c.load(commit, "stat"); ok && entry.Stat != nil {
+			stats[commit] = *entry.Stat
+			continue
+		}
+		missing = append(missing, commit)
+	}
+	if len(missing) == 0 {
+		return stats, nil
+	}
+
+	fetched, err := c.Provider.BuildStats(missing)
+	if _, ok := err.(*PartialStatsError); err != nil && !ok {
+		return nil, err
+	}
+	for commit, stat := range fetched {
+		stats[commit] = stat
+		if stat.terminal() {
+			stat := stat
+			c.store(commit, "stat", cacheEntry{Stat: &stat})
+		}
+	}
+	return stats, err
+}
+
+// CommitURL returns the web page of the commit, if known by the provider
+func (c *cachedProvider) CommitURL(commit CommitID) string {
+	return commitURL(c.Provider, commit)
+}
+
+// Close closes the wrapped provider, if it holds resources
+func (c *cachedProvider) Close() error {
+	if closer, ok := c.Provider.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// SetBuildStatus sets the build status and drops the cached entries of the
+// commit
+func (c *cachedProvider) SetBuildStatus(commit CommitID, bs BuildStatus) error {
+	for _, kind := range []string{"status", "stat"} {
+		if err := os.Remove(c.path(commit, kind)); err != nil && !os.IsNotExist(err) {
+			debug.Printf("Unable to drop cached %s for %s: %v", kind, commit, err)
+		}
+	}
+	return c.Provider.SetBuildStatus(commit, bs)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+)
+
+// Exit codes reflecting the build state, used by -check and -wait
+const (
+	exitSuccessful = 0
+	exitFailed     = 1
+	exitInProgress = 2
+	exitNoBuilds   = 3
+
+	// exitError is used when the build state could not be determined, e.g.
+	// on network, authentication or git errors, so scripts can tell errors
+	// from failed builds
+	exitError = 4
+
+	// exitTimeout is used when -wait gives up, the builds are still in
+	// progress or have not been reported
+	exitTimeout = exitInProgress
+)
+
+// exitCode maps the build stats to the exit codes used for scripting
+func (bs BuildStatusCommitStat) exitCode() int {
+	switch {
+	case bs.Failed > 0:
+		return exitFailed
+	case bs.InProgress > 0:
+		return exitInProgress
+	case bs.Successful > 0:
+		return exitSuccessful
+	}
+	return exitNoBuilds
+}
+
+// summary returns a one line summary of the build stats
+func (bs BuildStatusCommitStat) summary() string {
+	switch bs.exitCode() {
+	case exitFailed:
+		return fmt.Sprintf("%s: %s", BuildStateFailed, bs)
+	case exitInProgress:
+		return fmt.Sprintf("%s: %s", BuildStateInProgress, bs)
+	case exitSuccessful:
+		return fmt.Sprintf("%s: %s", BuildStateSuccessful, bs)
+	}
+	return "No builds reported"
+}
+
+// checkBuildState prints a summary of the build state for the commit and
+// returns an exit code reflecting it
+func (s *subcommand) checkBuildState() int {
+	debug.Printf("Git ref: %s", s.ref())
+
+	commit, err := commitIDFromRef(s.ref())
+	if err != nil {
+		return checkError(err)
+	}
+	debug.Printf("Git commit: %s", commit)
+	bs, err := s.provider.BuildStatus(commit)
+	if err != nil {
+		return checkError(err)
+	}
+
+	return s.printCheck(commit, bs.Stat())
+}
+
+// checkLog is like checkBuildState for the newest commit in the log
+func (s *subcommand) checkLog() int {
+	logs, err := gitLogShort(s.log, s.args)
+	if err != nil {
+		return checkError(err)
+	}
+	if len(logs) == 0 {
+		log.Printf("No commits found")
+		return exitNoBuilds
+	}
+
+	bs, err := s.provider.BuildStats(logs[:1])
+	if err != nil {
+		return checkError(err)
+	}
+
+	return s.printCheck(logs[0].id, bs[logs[0].id])
+}
+
+// checkError logs the error and returns exitError
+func checkError(err error) int {
+	if werr, ok := err.(*exec.ExitError); ok {
+		log.Printf("%s", werr.Stderr)
+	}
+	log.Printf("%v", err)
+	return exitError
+}
+
+func (s *subcommand) printCheck(commit CommitID, stat BuildStatusCommitStat) int {
+	fmt.Printf("%s %s\n", commit.abbrevCommit(), stat.summary())
+	return stat.exitCode()
+}
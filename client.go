@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHTTPTimeout        = 30 * time.Second
+	defaultHTTPConnectTimeout = 10 * time.Second
+	defaultHTTPRetries        = 3
+
+	// retryBaseDelay is the delay before the first retry, it doubles for each
+	// retry made
+	retryBaseDelay = 500 * time.Millisecond
+
+	// maxRetryAfter is the longest Retry-After honoured, requests asked to
+	// wait longer fail
+	maxRetryAfter = time.Minute
+)
+
+// httpClient is shared by all requests, see configureHTTP
+var (
+	httpClient  = newHTTPClient(defaultHTTPTimeout, defaultHTTPConnectTimeout)
+	httpRetries = defaultHTTPRetries
+)
+
+// configureHTTP sets up the shared client for the service URL from the
+// build-state.http.timeout, build-state.http.connectTimeout and
+// build-state.http.retries settings, and the TLS and proxy settings
+func configureHTTP(u *url.URL) error {
+	timeout, err := durationGitConfig("build-state.http.timeout", defaultHTTPTimeout)
+	if err != nil {
+		return err
+	}
+	connectTimeout, err := durationGitConfig("build-state.http.connectTimeout", defaultHTTPConnectTimeout)
+	if err != nil {
+		return err
+	}
+
+	retries := defaultHTTPRetries
+	if r := defaultGitConfig("build-state.http.retries"); r != "" {
+		if retries, err = strconv.Atoi(r); err != nil {
+			return fmt.Errorf("build-state.http.retries: %v", err)
+		}
+	}
+
+	client := newHTTPClient(timeout, connectTimeout)
+	transport := client.Transport.(*http.Transport)
+	if transport.TLSClientConfig, err = tlsConfig(u); err != nil {
+		return err
+	}
+	if transport.Proxy, err = proxyFunc(u); err != nil {
+		return err
+	}
+
+	debug.Printf("HTTP timeout: %s, connect timeout: %s, retries: %d", timeout, connectTimeout, retries)
+	httpClient = client
+	httpRetries = retries
+	return nil
+}
+
+// durationGitConfig reads a duration such as 30s from the git config
+func durationGitConfig(key string, def time.Duration) (time.Duration, error) {
+	v := defaultGitConfig(key)
+	if v == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", key, err)
+	}
+	return d, nil
+}
+
+// newHTTPClient returns a client giving up on connections after
+// connectTimeout and on requests after timeout, zero means no timeout
+func newHTTPClient(timeout, connectTimeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = connectTimeout
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
+
+type idempotentKey struct{}
+
+// withIdempotent marks the request as safe to retry, requests using GET and
+// HEAD always are
+func withIdempotent(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), idempotentKey{}, true))
+}
+
+func idempotent(req *http.Request) bool {
+	if req.Method == "GET" || req.Method == "HEAD" {
+		return true
+	}
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// apiErrorFunc decodes the error reported in a response body, nil is returned
+// for bodies not holding a known error
+type apiErrorFunc func(body []byte) error
+
+// doRequest authenticates and sends the request. The response body is
+// returned for successful requests, otherwise the error decoded by apiError.
+// Idempotent requests are retried on connection errors and server errors.
+func doRequest(a Authenticator, req *http.Request, apiError apiErrorFunc) (*http.Response, []byte, error) {
+	req = a.Auth(req)
+	debug.DumpRequest(req, req.Body != nil)
+
+	retries := 0
+	if idempotent(req) {
+		retries = httpRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, body, err := send(req)
+		if attempt >= retries {
+			return handleResponse(a, res, body, err, apiError)
+		}
+
+		delay, retry := retryDelay(res, err, attempt)
+		if !retry {
+			return handleResponse(a, res, body, err, apiError)
+		}
+		if err == nil {
+			err = fmt.Errorf("%s", res.Status)
+		}
+		debug.Printf("Retrying %s %s in %s: %v", req.Method, req.URL, delay.Round(time.Millisecond), err)
+		time.Sleep(delay)
+
+		if req, err = rewind(req); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// send sends the request and reads the response body
+func send(req *http.Request) (*http.Response, []byte, error) {
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	debug.Printf("Response: %s %s", res.Status, body)
+	return res, body, nil
+}
+
+// handleResponse approves the credentials of successful requests, or decodes
+// the error of failed ones
+func handleResponse(a Authenticator, res *http.Response, body []byte, err error, apiError apiErrorFunc) (*http.Response, []byte, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.StatusCode/100 == 2 {
+		authApproved(a)
+		return res, body, nil
+	}
+
+	err = apiError(body)
+	if err == nil {
+		err = fmt.Errorf("%s: %s", res.Status, body)
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		err = authRejected(a, err)
+	}
+	return res, nil, err
+}
+
+// retryDelay reports if the request should be retried and after how long.
+// Connection errors and server errors are retried with exponential backoff
+// and jitter, unless the server asks for a delay with Retry-After.
+func retryDelay(res *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil && tlsError(err) {
+		return 0, false
+	}
+	if err == nil {
+		switch {
+		case res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable:
+			if d, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+				return d, d <= maxRetryAfter
+			}
+		case res.StatusCode/100 != 5:
+			return 0, false
+		}
+	}
+
+	backoff := retryBaseDelay << uint(attempt)
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1)), true
+}
+
+// tlsError reports if the error is caused by certificates, which retrying
+// will not help. Alerts sent by the server, e.g. for a missing client
+// certificate, are only exposed through the message.
+func tlsError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	return errors.As(err, &verifyErr) || strings.Contains(err.Error(), "remote error: tls:")
+}
+
+// parseRetryAfter parses a Retry-After header, given in seconds or as a date
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// rewind returns a copy of the request with the body reset, so it can be
+// sent again
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+// pageFunc decodes a page of a list, it returns the URL of the next page if
+// the API links it from the body
+type pageFunc func(body []byte) (next string, err error)
+
+// getPages fetches all pages of a list starting at URL, calling page for each
+// response body. The next page is the one returned by page, or else the next
+// link of the Link header. A positive pageSize is set as the sizeParam query
+// parameter of every page, header is added to each request.
+func getPages(a Authenticator, apiErr apiErrorFunc, URL string, pageSize int, sizeParam string, header http.Header, page pageFunc) error {
+	for URL != "" {
+		if pageSize > 0 {
+			u, err := url.Parse(URL)
+			if err != nil {
+				return err
+			}
+			query := u.Query()
+			query.Set(sizeParam, strconv.Itoa(pageSize))
+			u.RawQuery = query.Encode()
+			URL = u.String()
+		}
+
+		req, err := http.NewRequest("GET", URL, nil)
+		if err != nil {
+			return err
+		}
+		for name, values := range header {
+			req.Header[name] = values
+		}
+
+		res, body, err := doRequest(a, req, apiErr)
+		if err != nil {
+			return err
+		}
+		next, err := page(body)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			next = nextPageURL(res)
+		}
+		URL = next
+	}
+	return nil
+}
+
+// nextPageURL returns the URL of the next page from the Link header of the
+// response, or an empty string on the last page
+func nextPageURL(res *http.Response) string {
+	for _, link := range strings.Split(res.Header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
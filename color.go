@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// colorEnabled is set when output is coloured and may contain hyperlinks,
+// see setupColor
+var colorEnabled bool
+
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// ansiColors are the colours and attributes available to templates, build
+// states are coloured by their state
+var ansiColors = map[string]string{
+	"bold":                       "1",
+	"dim":                        "2",
+	"red":                        "31",
+	"green":                      "32",
+	"yellow":                     "33",
+	"blue":                       "34",
+	"magenta":                    "35",
+	"cyan":                       "36",
+	string(BuildStateSuccessful): "32",
+	string(BuildStateInProgress): "33",
+	string(BuildStateFailed):     "31",
+}
+
+// stateGlyphs are the glyphs of the build states
+var stateGlyphs = map[string]string{
+	string(BuildStateSuccessful): "✔",
+	string(BuildStateInProgress): "●",
+	string(BuildStateFailed):     "✘",
+}
+
+// setupColor enables colour according to the -color mode. In auto mode
+// colour is disabled by NO_COLOR, otherwise it is decided by git's
+// color.build-state and color.ui settings, which default to colour when
+// stdout is a terminal.
+func setupColor(mode string) error {
+	switch mode {
+	case colorAlways:
+		colorEnabled = true
+	case colorNever:
+		colorEnabled = false
+	case colorAuto, "":
+		if os.Getenv("NO_COLOR") != "" {
+			colorEnabled = false
+			break
+		}
+		tty := terminal.IsTerminal(int(os.Stdout.Fd()))
+		enabled, err := gitConfigGet("config", "--get-colorbool", "color.build-state", strconv.FormatBool(tty))
+		if err != nil {
+			return err
+		}
+		colorEnabled = enabled == "true"
+	default:
+		return fmt.Errorf("invalid -color: %q, expected auto, always or never", mode)
+	}
+	debug.Printf("Color: %t", colorEnabled)
+	return nil
+}
+
+// colorize colours the text by a build state or a colour name such as red or
+// bold, several names may be separated by space
+func colorize(color interface{}, text ...interface{}) string {
+	s := fmt.Sprint(text...)
+	if !colorEnabled {
+		return s
+	}
+
+	var codes []string
+	for _, name := range strings.Fields(fmt.Sprint(color)) {
+		if code, ok := ansiColors[name]; ok {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return s
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m" + s + "\x1b[0m"
+}
+
+// glyph returns the glyph of the build state
+func glyph(state interface{}) string {
+	return stateGlyphs[fmt.Sprint(state)]
+}
+
+// hyperlink makes the text an OSC 8 hyperlink to the URL, when colour is
+// enabled and the URL is known
+func hyperlink(url interface{}, text ...interface{}) string {
+	s := fmt.Sprint(text...)
+	u := fmt.Sprint(url)
+	if !colorEnabled || u == "" {
+		return s
+	}
+	return "\x1b]8;;" + u + "\x1b\\" + s + "\x1b]8;;\x1b\\"
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Views formats are used with, the prompt view is executed with the same
+// data as the log view
+const (
+	formatViewState  = "state"
+	formatViewLog    = "log"
+	formatViewPrompt = "prompt"
+)
+
+const (
+	// statBadgeTemplate is the build statistics as e.g. ✔3 ●1 ✘0
+	statBadgeTemplate = `{{color "SUCCESSFUL" (glyph "SUCCESSFUL") .Status.Successful}} {{color "INPROGRESS" (glyph "INPROGRESS") .Status.InProgress}} {{color "FAILED" (glyph "FAILED") .Status.Failed}}`
+
+	// statTokenTemplate is the states with builds as e.g. ✘1●1✔3
+	statTokenTemplate = `{{with .Status}}{{if .Failed}}{{color "FAILED" (glyph "FAILED") .Failed}}{{end}}{{if .InProgress}}{{color "INPROGRESS" (glyph "INPROGRESS") .InProgress}}{{end}}{{if .Successful}}{{color "SUCCESSFUL" (glyph "SUCCESSFUL") .Successful}}{{end}}{{end}}`
+)
+
+// builtinFormats are the named formats of each view, the state view is
+// executed for each build and a new line is added after each
+var builtinFormats = map[string]map[string]string{
+	formatViewState: {
+		"oneline": `{{color .State (glyph .State)}} {{pad 10 .State}} {{pad 20 .Key}} {{.Name}}`,
+		"short": `{{color .State (glyph .State) " " .State}}  {{.Name}}
+{{link .URL .URL}}
+`,
+		"full": `Commit: {{link .CommitURL .Commit}}
+Name:   {{.Name}}     Key: {{.Key}}
+State:  {{color .State (glyph .State) " " .State}}
+URL:    {{link .URL .URL}}
+Date:   {{date "2006-01-02 15:04:05 -0700" .DateAdded}} ({{since .DateAdded}})
+
+   {{.Description}}
+`,
+		"prompt": `{{color .State (glyph .State)}} {{.Key}}`,
+	},
+	formatViewLog: {
+		"oneline": `{{link .CommitURL (color "yellow" (abbrev .ID))}} ` + statBadgeTemplate + ` {{.Message}}
+`,
+		"short": buildStatusDefaultTemplate,
+		"full": `commit {{link .CommitURL (color "yellow" .ID)}}
+
+    {{.Message}}
+
+    ` + statBadgeTemplate + `
+
+`,
+		"prompt": statTokenTemplate + "\n",
+	},
+}
+
+// formatTemplate returns the template of the view for the -format value. An
+// empty format selects build-state.format.<view>, or the default of the
+// view.
+func formatTemplate(format, view string) (string, error) {
+	if format == "" {
+		format = defaultGitConfig("build-state.format." + view)
+	}
+	if format == "" {
+		return defaultFormat(view), nil
+	}
+	return resolveFormat(format, view)
+}
+
+func defaultFormat(view string) string {
+	switch {
+	case view == formatViewPrompt:
+		return builtinFormats[formatViewLog]["prompt"]
+	case view == formatViewLog && colorEnabled:
+		return buildStatusColorTemplate
+	case view == formatViewLog:
+		return buildStatusDefaultTemplate
+	case colorEnabled:
+		return buildStateColorTemplate
+	}
+	return buildStateDefaultTemplate
+}
+
+// resolveFormat resolves named formats and template files. The format
+// name:<name> selects a built-in format or build-state.pretty.<name>, as does
+// a known name given without name:. The format @<path> reads the template
+// from the file. Anything else is a template.
+func resolveFormat(format, view string) (string, error) {
+	switch {
+	case strings.HasPrefix(format, "@"):
+		return readFormatFile(format[1:])
+	case strings.HasPrefix(format, "name:"):
+		return namedFormat(strings.TrimPrefix(format, "name:"), view)
+	case !strings.Contains(format, "{{"):
+		if f, err := namedFormat(format, view); err == nil {
+			return f, nil
+		}
+	}
+	return format, nil
+}
+
+// namedFormat returns the built-in format of the view, or the
+// build-state.pretty.<name> format. As with git's pretty formats, built-in
+// names can not be redefined.
+func namedFormat(name, view string) (string, error) {
+	builtinView := view
+	if view == formatViewPrompt {
+		builtinView = formatViewLog
+	}
+	if f, ok := builtinFormats[builtinView][name]; ok {
+		return f, nil
+	}
+
+	f := defaultGitConfig("build-state.pretty." + name)
+	if f == "" {
+		return "", fmt.Errorf("unknown format: %s", name)
+	}
+	if strings.HasPrefix(f, "@") {
+		return readFormatFile(f[1:])
+	}
+	return f, nil
+}
+
+// readFormatFile reads a template file, ~/ is the home directory
+func readFormatFile(path string) (string, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read format: %v", err)
+	}
+	return string(b), nil
+}
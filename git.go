@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
-	"net/url"
+	"os"
 	"os/exec"
 	"strings"
 	"syscall"
@@ -25,17 +25,43 @@ func (sh shortLog) CommitIDs() CommitIDs {
 	return log
 }
 
-func gitLogShort(branch string) (shortLog, error) {
-	if branch == "" {
-		b, err := gitCurrentBranch()
-		if err != nil {
-			return nil, err
-		}
-		branch = b
+// logOptions selects the commits displayed with -log
+type logOptions struct {
+	maxCount    int
+	firstParent bool
+	since       string
+	until       string
+	author      string
+
+	// graph draws the commit graph, see displayLogGraph
+	graph bool
+}
+
+// gitArgs returns the git log options for the format, the revisions and
+// paths in args are passed through as is
+func (o logOptions) gitArgs(format string, args []string) []string {
+	gitArgs := []string{"log", "--format=" + format, fmt.Sprintf("--max-count=%d", o.maxCount)}
+	if o.firstParent {
+		gitArgs = append(gitArgs, "--first-parent")
+	}
+	if o.since != "" {
+		gitArgs = append(gitArgs, "--since="+o.since)
+	}
+	if o.until != "" {
+		gitArgs = append(gitArgs, "--until="+o.until)
 	}
+	if o.author != "" {
+		gitArgs = append(gitArgs, "--author="+o.author)
+	}
+	return append(gitArgs, args...)
+}
 
+// gitLogShort lists the commits selected by the options and the revision
+// ranges and paths in args, it defaults to the current branch
+func gitLogShort(opts logOptions, args []string) (shortLog, error) {
 	var logs shortLog
-	cmd := exec.Command("git", "log", "-8", "--pretty=oneline", branch)
+	cmd := exec.Command("git", opts.gitArgs("%H %s", args)...)
+	debug.Printf("Git log: %q", cmd.Args)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -58,23 +84,73 @@ func gitCurrentBranch() (string, error) {
 	return string(output), err
 }
 
-func gitRemote() (*url.URL, error) {
-	output, err := exec.Command("git", "remote", "-v").Output()
+// gitRemote returns the URL of the named remote
+func gitRemote(name string) (string, error) {
+	output, err := exec.Command("git", "remote", "get-url", name).Output()
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// gitRemoteName returns the remote to use. It defaults to the upstream remote
+// of the current branch, then origin, then the first remote listed.
+func gitRemoteName() (string, error) {
+	if name := defaultGitConfig("build-state.remote"); name != "" {
+		return name, nil
 	}
 
-	lines := bytes.Split(output, []byte("\n"))
-	if len(lines) == 0 {
-		return nil, fmt.Errorf("No output when trying to fetch git remote")
+	if branch, err := gitCurrentBranch(); err == nil && branch != "HEAD" {
+		if name := defaultGitConfig("branch." + branch + ".remote"); name != "" && name != "." {
+			return name, nil
+		}
+	}
+
+	output, err := exec.Command("git", "remote").Output()
+	if err != nil {
+		return "", err
+	}
+	remotes := strings.Fields(string(output))
+	for _, name := range remotes {
+		if name == "origin" {
+			return name, nil
+		}
+	}
+	if len(remotes) == 0 {
+		return "", fmt.Errorf("No git remote configured")
 	}
-	parts := strings.Fields(string(lines[0]))
-	remote, err := url.Parse(string(parts[1]))
+	return remotes[0], nil
+}
+
+// gitConfigRegexp returns the keys and values of all configuration variables
+// with names matching the regular expression
+func gitConfigRegexp(regexp string) ([][2]string, error) {
+	output, err := exec.Command("git", "config", "--get-regexp", regexp).Output()
 	if err != nil {
-		return nil, fmt.Errorf("Unable to parse remote: %v", err)
+		if exitCode(err) == 1 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var vars [][2]string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		vars = append(vars, [2]string{parts[0], parts[1]})
 	}
+	return vars, nil
+}
 
-	return remote, nil
+// gitCredential runs `git credential <action>` with the credential
+// description as input, see git-credential(1)
+func gitCredential(action string, description []byte) ([]byte, error) {
+	cmd := exec.Command("git", "credential", action)
+	cmd.Stdin = bytes.NewReader(description)
+	cmd.Stderr = os.Stderr
+	return cmd.Output()
 }
 
 func gitConfig(key string) (string, error) {
@@ -86,24 +162,41 @@ func gitConfig(key string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-func setGitConfig(key, value string) error {
-	return exec.Command("git", "config", "--global", key, value).Run()
+// gitConfigURLMatch returns the value of key for the URL, settings for the
+// most specific URL, such as http.<url>.<name>, take precedence. The options
+// are passed to git config, e.g. --type=bool. An empty string is returned if
+// the key does not exist.
+func gitConfigURLMatch(key, URL string, opts ...string) (string, error) {
+	args := append(append([]string{"config"}, opts...), "--get-urlmatch", key, URL)
+	return gitConfigGet(args...)
 }
 
-// mustGitConfig returns the given configuration value for the key. All errors
-// will terminate the execution.
-func mustGitConfig(key string) string {
-	value, err := gitConfig(key)
+// gitConfigTyped returns the value of key converted by git config according
+// to the options, e.g. --type=path. An empty string is returned if the key
+// does not exist.
+func gitConfigTyped(key string, opts ...string) (string, error) {
+	args := append(append([]string{"config"}, opts...), "--get", key)
+	return gitConfigGet(args...)
+}
+
+func gitConfigGet(args ...string) (string, error) {
+	output, err := exec.Command("git", args...).Output()
 	if err != nil {
-		log.Fatalf("Unable to fetch git config %s: %v", key, err)
+		if exitCode(err) == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("git config %s: %v", strings.Join(args[1:], " "), err)
 	}
-	return value
+	return strings.TrimSpace(string(output)), nil
+}
+
+func setGitConfig(key, value string) error {
+	return exec.Command("git", "config", "--global", key, value).Run()
 }
 
-// defaultGitConfig is a special case of mustGitConfig. It returns the given
-// configuration value for the key. If the key does not exist in the
-// configuration an empty string will be returned. All other errors will abort
-// execution.
+// defaultGitConfig returns the given configuration value for the key. If the
+// key does not exist in the configuration an empty string will be returned.
+// All other errors will abort execution.
 func defaultGitConfig(key string) string {
 	value, err := gitConfig(key)
 	if err != nil {
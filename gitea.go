@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GiteaService implements the Provider interface for Gitea and Forgejo
+type GiteaService struct {
+	url           *url.URL
+	authenticator Authenticator
+	repository    Repository
+	listOptions
+}
+
+func newGiteaService(URL *url.URL, a Authenticator, repo Repository, opts listOptions) *GiteaService {
+	return &GiteaService{
+		url:           URL,
+		authenticator: a,
+		repository:    repo,
+		listOptions:   opts,
+	}
+}
+
+// isGiteaHost tells if the host is a known Gitea or Forgejo instance
+func isGiteaHost(host string) bool {
+	return host == "gitea.com" || host == "codeberg.org"
+}
+
+// giteaAPIURL returns build-state.endpoint, or the API URL below the base URL
+// of the remote. HTTP remotes keep their scheme and port, other remotes use
+// proto, unless rewritten by build-state.url.<base>.insteadOf.
+func giteaAPIURL(proto, remote string) (*url.URL, error) {
+	if endpoint := defaultGitConfig("build-state.endpoint"); endpoint != "" {
+		return url.Parse(endpoint)
+	}
+	api, err := remoteBaseURL(proto, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	api.Path = strings.TrimSuffix(api.Path, "/") + "/api/v1"
+	return api, nil
+}
+
+func (s *GiteaService) repoURL() (string, error) {
+	if s.repository.Project == "" || s.repository.Slug == "" {
+		return "", fmt.Errorf("unable to derive the Gitea owner and repository from the git remote")
+	}
+	return fmt.Sprintf("%s/repos/%s/%s", s.url, url.PathEscape(s.repository.Project), url.PathEscape(s.repository.Slug)), nil
+}
+
+// CommitURL returns the web page of the commit
+func (s *GiteaService) CommitURL(c CommitID) string {
+	if s.repository.Project == "" || s.repository.Slug == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/%s/commit/%s", webURL(s.url, "/api/v1"), s.repository.Project, s.repository.Slug, c)
+}
+
+func (s *GiteaService) do(req *http.Request) ([]byte, error) {
+	_, body, err := doRequest(s.authenticator, req, giteaAPIError)
+	return body, err
+}
+
+// BuildStatus provides the latest status of each context from the combined
+// status of the commit. All pages are fetched.
+func (s *GiteaService) BuildStatus(c CommitID) (BuildStatusResponse, error) {
+	repoURL, err := s.repoURL()
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	// The combined status is paged by number, the total count tells when
+	// all statuses have been read
+	statusURL := fmt.Sprintf("%s/commits/%s/status", repoURL, c)
+	var values []BuildStatus
+	page := 1
+	err = getPages(s.authenticator, giteaAPIError, statusURL+"?page=1", s.pageSize, "limit", nil, func(body []byte) (string, error) {
+		var combined struct {
+			TotalCount int `json:"total_count"`
+			Statuses   []struct {
+				Status      string    `json:"status"`
+				Context     string    `json:"context"`
+				Description string    `json:"description"`
+				TargetURL   string    `json:"target_url"`
+				UpdatedAt   StashTime `json:"updated_at"`
+			} `json:"statuses"`
+		}
+		if err := json.Unmarshal(body, &combined); err != nil {
+			return "", err
+		}
+
+		for _, status := range combined.Statuses {
+			values = append(values, BuildStatus{
+				State:       giteaState(status.Status),
+				Key:         status.Context,
+				Name:        status.Context,
+				URL:         status.TargetURL,
+				Description: status.Description,
+				DateAdded:   status.UpdatedAt,
+			})
+		}
+
+		if len(combined.Statuses) == 0 || len(values) >= combined.TotalCount {
+			return "", nil
+		}
+		page++
+		return fmt.Sprintf("%s?page=%d", statusURL, page), nil
+	})
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	return newBuildStatusResponse(values), nil
+}
+
+// giteaState maps commit status states onto build states, warnings do not
+// fail the commit
+func giteaState(status string) BuildState {
+	switch status {
+	case "success", "warning":
+		return BuildStateSuccessful
+	case "pending":
+		return BuildStateInProgress
+	default:
+		return BuildStateFailed
+	}
+}
+
+// BuildStats lists status given commit ids. Gitea has no batch API, the
+// statuses of each commit are fetched concurrently.
+func (s *GiteaService) BuildStats(c CommitIDer) (BuildStatusCommitStats, error) {
+	return collectBuildStats(c, s.concurrency, s.BuildStatus)
+}
+
+// SetBuildStatus creates a commit status, the key is used as context
+func (s *GiteaService) SetBuildStatus(c CommitID, bs BuildStatus) error {
+	repoURL, err := s.repoURL()
+	if err != nil {
+		return err
+	}
+
+	state := "failure"
+	switch bs.State {
+	case BuildStateSuccessful:
+		state = "success"
+	case BuildStateInProgress:
+		state = "pending"
+	}
+
+	b, err := json.Marshal(struct {
+		State       string `json:"state"`
+		TargetURL   string `json:"target_url,omitempty"`
+		Description string `json:"description,omitempty"`
+		Context     string `json:"context"`
+	}{state, bs.URL, bs.Description, bs.Key})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/statuses/%s", repoURL, c), bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = s.do(req)
+	return err
+}
+
+// GiteaError is used for unmarshaling JSON errors from Gitea
+type GiteaError struct {
+	Message string `json:"message"`
+}
+
+// giteaAPIError is the apiErrorFunc for Gitea
+func giteaAPIError(b []byte) error {
+	var e GiteaError
+	if err := json.Unmarshal(b, &e); err != nil || e.Message == "" {
+		return nil
+	}
+	return e
+}
+
+func (e GiteaError) Error() string {
+	return e.Message
+}
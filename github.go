@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// gitHubAccept is the media type requested from the GitHub API
+const gitHubAccept = "application/vnd.github+json"
+
+// GitHubService implements the Provider interface for GitHub and GitHub
+// Enterprise, using the combined commit status and check runs APIs
+type GitHubService struct {
+	url           *url.URL
+	authenticator Authenticator
+	repository    Repository
+	listOptions
+}
+
+func newGitHubService(URL *url.URL, a Authenticator, repo Repository, opts listOptions) *GitHubService {
+	return &GitHubService{
+		url:           URL,
+		authenticator: a,
+		repository:    repo,
+		listOptions:   opts,
+	}
+}
+
+// isGitHubHost tells if the host looks like GitHub or GitHub Enterprise
+func isGitHubHost(host string) bool {
+	return host == "github.com" || strings.HasPrefix(host, "github.")
+}
+
+// gitHubAPIURL returns build-state.endpoint, or the API URL of the host.
+// GitHub Enterprise serves the API under /api/v3.
+func gitHubAPIURL(repo Repository) (*url.URL, error) {
+	if endpoint := defaultGitConfig("build-state.endpoint"); endpoint != "" {
+		return url.Parse(endpoint)
+	}
+	if repo.Host == "" || repo.Host == "github.com" {
+		return url.Parse("https://api.github.com")
+	}
+	return url.Parse("https://" + repo.Host + "/api/v3")
+}
+
+func (s *GitHubService) repoURL() (string, error) {
+	if s.repository.Project == "" || s.repository.Slug == "" {
+		return "", fmt.Errorf("unable to derive the GitHub owner and repository from the git remote")
+	}
+	return fmt.Sprintf("%s/repos/%s/%s", s.url, url.PathEscape(s.repository.Project), url.PathEscape(s.repository.Slug)), nil
+}
+
+// CommitURL returns the web page of the commit
+func (s *GitHubService) CommitURL(c CommitID) string {
+	if s.repository.Project == "" || s.repository.Slug == "" {
+		return ""
+	}
+	web := "https://github.com"
+	if s.url.Host != "api.github.com" {
+		web = webURL(s.url, "/api/v3")
+	}
+	return fmt.Sprintf("%s/%s/%s/commit/%s", web, s.repository.Project, s.repository.Slug, c)
+}
+
+func (s *GitHubService) do(req *http.Request) (*http.Response, []byte, error) {
+	req.Header.Set("Accept", gitHubAccept)
+	return doRequest(s.authenticator, req, gitHubAPIError)
+}
+
+// getPages fetches all pages starting at URL, calling page for each response
+// body
+func (s *GitHubService) getPages(URL string, page func([]byte) error) error {
+	header := http.Header{}
+	header.Set("Accept", gitHubAccept)
+	return getPages(s.authenticator, gitHubAPIError, URL, s.pageSize, "per_page", header, func(body []byte) (string, error) {
+		return "", page(body)
+	})
+}
+
+// BuildStatus provides the commit statuses and check runs of the commit
+func (s *GitHubService) BuildStatus(c CommitID) (BuildStatusResponse, error) {
+	repoURL, err := s.repoURL()
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	var values []BuildStatus
+	err = s.getPages(fmt.Sprintf("%s/commits/%s/status", repoURL, c), func(body []byte) error {
+		var combined struct {
+			Statuses []struct {
+				State       string    `json:"state"`
+				Context     string    `json:"context"`
+				Description string    `json:"description"`
+				TargetURL   string    `json:"target_url"`
+				UpdatedAt   StashTime `json:"updated_at"`
+			} `json:"statuses"`
+		}
+		if err := json.Unmarshal(body, &combined); err != nil {
+			return err
+		}
+		for _, status := range combined.Statuses {
+			values = append(values, BuildStatus{
+				State:       gitHubStatusState(status.State),
+				Key:         status.Context,
+				Name:        status.Context,
+				URL:         status.TargetURL,
+				Description: status.Description,
+				DateAdded:   status.UpdatedAt,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	err = s.getPages(fmt.Sprintf("%s/commits/%s/check-runs", repoURL, c), func(body []byte) error {
+		var checks struct {
+			CheckRuns []struct {
+				Name        string     `json:"name"`
+				Status      string     `json:"status"`
+				Conclusion  string     `json:"conclusion"`
+				HTMLURL     string     `json:"html_url"`
+				DetailsURL  string     `json:"details_url"`
+				StartedAt   *StashTime `json:"started_at"`
+				CompletedAt *StashTime `json:"completed_at"`
+				Output      struct {
+					Title string `json:"title"`
+				} `json:"output"`
+			} `json:"check_runs"`
+		}
+		if err := json.Unmarshal(body, &checks); err != nil {
+			return err
+		}
+		for _, check := range checks.CheckRuns {
+			bs := BuildStatus{
+				State:       gitHubCheckState(check.Status, check.Conclusion),
+				Key:         check.Name,
+				Name:        check.Name,
+				URL:         check.DetailsURL,
+				Description: check.Output.Title,
+			}
+			if bs.URL == "" {
+				bs.URL = check.HTMLURL
+			}
+			switch {
+			case check.CompletedAt != nil:
+				bs.DateAdded = *check.CompletedAt
+			case check.StartedAt != nil:
+				bs.DateAdded = *check.StartedAt
+			}
+			values = append(values, bs)
+		}
+		return nil
+	})
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	return newBuildStatusResponse(values), nil
+}
+
+// gitHubStatusState maps commit status states onto build states
+func gitHubStatusState(state string) BuildState {
+	switch state {
+	case "success":
+		return BuildStateSuccessful
+	case "pending":
+		return BuildStateInProgress
+	default:
+		return BuildStateFailed
+	}
+}
+
+// gitHubCheckState maps check run statuses and conclusions onto build states.
+// Neutral and skipped checks do not fail the commit.
+func gitHubCheckState(status, conclusion string) BuildState {
+	if status != "completed" {
+		return BuildStateInProgress
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return BuildStateSuccessful
+	default:
+		return BuildStateFailed
+	}
+}
+
+// BuildStats lists status given commit ids. GitHub has no batch API, the
+// statuses of each commit are fetched.
+func (s *GitHubService) BuildStats(c CommitIDer) (BuildStatusCommitStats, error) {
+	return collectBuildStats(c, s.concurrency, s.BuildStatus)
+}
+
+// SetBuildStatus creates a commit status, the key is used as context
+func (s *GitHubService) SetBuildStatus(c CommitID, bs BuildStatus) error {
+	repoURL, err := s.repoURL()
+	if err != nil {
+		return err
+	}
+
+	state := "failure"
+	switch bs.State {
+	case BuildStateSuccessful:
+		state = "success"
+	case BuildStateInProgress:
+		state = "pending"
+	}
+
+	b, err := json.Marshal(struct {
+		State       string `json:"state"`
+		TargetURL   string `json:"target_url,omitempty"`
+		Description string `json:"description,omitempty"`
+		Context     string `json:"context"`
+	}{state, bs.URL, bs.Description, bs.Key})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/statuses/%s", repoURL, c), bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, _, err = s.do(req)
+	return err
+}
+
+// GitHubError is used for unmarshaling JSON errors from GitHub
+type GitHubError struct {
+	Message string `json:"message"`
+	Errors  []struct {
+		Message string `json:"message"`
+		Field   string `json:"field"`
+		Code    string `json:"code"`
+	} `json:"errors"`
+}
+
+// gitHubAPIError is the apiErrorFunc for GitHub
+func gitHubAPIError(b []byte) error {
+	var e GitHubError
+	if err := json.Unmarshal(b, &e); err != nil || e.Message == "" {
+		return nil
+	}
+	return e
+}
+
+func (e GitHubError) Error() string {
+	var buf bytes.Buffer
+	buf.WriteString(e.Message)
+	for _, err := range e.Errors {
+		buf.WriteString("\n")
+		if err.Message != "" {
+			buf.WriteString(err.Message)
+			continue
+		}
+		fmt.Fprintf(&buf, "%s: %s", err.Field, err.Code)
+	}
+	return buf.String()
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GitLabService implements the Provider interface for GitLab, using the
+// commit status and pipeline APIs
+type GitLabService struct {
+	url           *url.URL
+	authenticator Authenticator
+	repository    Repository
+	listOptions
+}
+
+func newGitLabService(URL *url.URL, a Authenticator, repo Repository, opts listOptions) *GitLabService {
+	return &GitLabService{
+		url:           URL,
+		authenticator: a,
+		repository:    repo,
+		listOptions:   opts,
+	}
+}
+
+// isGitLabHost tells if the host looks like GitLab
+func isGitLabHost(host string) bool {
+	return host == "gitlab.com" || strings.HasPrefix(host, "gitlab.")
+}
+
+// gitLabAPIURL returns build-state.endpoint, or the API URL below the base URL
+// of the remote. HTTP remotes keep their scheme and port, other remotes use
+// proto, unless rewritten by build-state.url.<base>.insteadOf.
+func gitLabAPIURL(proto, remote string) (*url.URL, error) {
+	if endpoint := defaultGitConfig("build-state.endpoint"); endpoint != "" {
+		return url.Parse(endpoint)
+	}
+	api, err := remoteBaseURL(proto, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	api.Path = strings.TrimSuffix(api.Path, "/") + "/api/v4"
+	return api, nil
+}
+
+// CommitURL returns the web page of the commit
+func (s *GitLabService) CommitURL(c CommitID) string {
+	if s.repository.Slug == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/-/commit/%s", webURL(s.url, "/api/v4"), s.repository.Path(), c)
+}
+
+// projectURL returns the project API URL, the project ID is the URL encoded
+// path of the repository
+func (s *GitLabService) projectURL() (string, error) {
+	if s.repository.Slug == "" {
+		return "", fmt.Errorf("unable to derive the GitLab project from the git remote")
+	}
+	return fmt.Sprintf("%s/projects/%s", s.url, url.PathEscape(s.repository.Path())), nil
+}
+
+func (s *GitLabService) do(req *http.Request) (*http.Response, []byte, error) {
+	return doRequest(s.authenticator, req, gitLabAPIError)
+}
+
+// getPages fetches all pages starting at URL, calling page for each response
+// body
+func (s *GitLabService) getPages(URL string, page func([]byte) error) error {
+	return getPages(s.authenticator, gitLabAPIError, URL, s.pageSize, "per_page", nil, func(body []byte) (string, error) {
+		return "", page(body)
+	})
+}
+
+// BuildStatus provides the latest commit statuses, such as jobs and external
+// statuses, of the commit. A retried job replaces its earlier status, and jobs
+// that are allowed to fail do not affect the commit and are skipped. The
+// pipelines are made up of the same jobs, they are only read when the commit
+// has no statuses, e.g. a pipeline that has not created its jobs yet or failed
+// before it could.
+func (s *GitLabService) BuildStatus(c CommitID) (BuildStatusResponse, error) {
+	projectURL, err := s.projectURL()
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	var values []BuildStatus
+	err = s.getPages(fmt.Sprintf("%s/repository/commits/%s/statuses", projectURL, c), func(body []byte) error {
+		var page []struct {
+			Status       string     `json:"status"`
+			Name         string     `json:"name"`
+			TargetURL    string     `json:"target_url"`
+			Description  string     `json:"description"`
+			CreatedAt    StashTime  `json:"created_at"`
+			FinishedAt   *StashTime `json:"finished_at"`
+			AllowFailure bool       `json:"allow_failure"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return err
+		}
+		for _, status := range page {
+			if status.AllowFailure {
+				continue
+			}
+			bs := BuildStatus{
+				State:       gitLabState(status.Status),
+				Key:         status.Name,
+				Name:        status.Name,
+				URL:         status.TargetURL,
+				Description: status.Description,
+				DateAdded:   status.CreatedAt,
+			}
+			if status.FinishedAt != nil {
+				bs.DateAdded = *status.FinishedAt
+			}
+			values = append(values, bs)
+		}
+		return nil
+	})
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	if len(values) == 0 {
+		if values, err = s.pipelines(projectURL, c); err != nil {
+			return BuildStatusResponse{}, err
+		}
+	}
+
+	return newBuildStatusResponse(values), nil
+}
+
+// pipelines returns the latest pipeline of each ref and source of the commit.
+// Earlier pipelines have been superseded, e.g. by a retry.
+func (s *GitLabService) pipelines(projectURL string, c CommitID) ([]BuildStatus, error) {
+	type pipeline struct {
+		ID        int       `json:"id"`
+		Status    string    `json:"status"`
+		Ref       string    `json:"ref"`
+		Source    string    `json:"source"`
+		WebURL    string    `json:"web_url"`
+		UpdatedAt StashTime `json:"updated_at"`
+	}
+
+	var order []string
+	latest := map[string]pipeline{}
+	query := url.Values{}
+	query.Set("sha", string(c))
+	err := s.getPages(projectURL+"/pipelines?"+query.Encode(), func(body []byte) error {
+		var page []pipeline
+		if err := json.Unmarshal(body, &page); err != nil {
+			return err
+		}
+		for _, p := range page {
+			key := p.Source + "/" + p.Ref
+			prev, ok := latest[key]
+			if !ok {
+				order = append(order, key)
+			}
+			if !ok || p.ID > prev.ID {
+				latest[key] = p
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var values []BuildStatus
+	for _, key := range order {
+		p := latest[key]
+		values = append(values, BuildStatus{
+			State:       gitLabState(p.Status),
+			Key:         "pipeline/" + key,
+			Name:        fmt.Sprintf("Pipeline #%d", p.ID),
+			URL:         p.WebURL,
+			Description: fmt.Sprintf("%s pipeline for %s", p.Source, p.Ref),
+			DateAdded:   p.UpdatedAt,
+		})
+	}
+	return values, nil
+}
+
+// gitLabState maps pipeline and commit status states onto build states.
+// Skipped and manual jobs do not block or fail the commit.
+func gitLabState(status string) BuildState {
+	switch status {
+	case "success", "skipped", "manual":
+		return BuildStateSuccessful
+	case "failed", "canceled":
+		return BuildStateFailed
+	default:
+		// created, pending, running, preparing, scheduled and
+		// waiting_for_resource
+		return BuildStateInProgress
+	}
+}
+
+// BuildStats lists status given commit ids. GitLab has no batch API, the
+// statuses of each commit are fetched concurrently.
+func (s *GitLabService) BuildStats(c CommitIDer) (BuildStatusCommitStats, error) {
+	return collectBuildStats(c, s.concurrency, s.BuildStatus)
+}
+
+// SetBuildStatus creates a commit status, the key is used as name
+func (s *GitLabService) SetBuildStatus(c CommitID, bs BuildStatus) error {
+	projectURL, err := s.projectURL()
+	if err != nil {
+		return err
+	}
+
+	state := "failed"
+	switch bs.State {
+	case BuildStateSuccessful:
+		state = "success"
+	case BuildStateInProgress:
+		state = "running"
+	}
+
+	b, err := json.Marshal(struct {
+		State       string `json:"state"`
+		Name        string `json:"name"`
+		TargetURL   string `json:"target_url,omitempty"`
+		Description string `json:"description,omitempty"`
+	}{state, bs.Key, bs.URL, bs.Description})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/statuses/%s", projectURL, c), bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, _, err = s.do(req)
+	return err
+}
+
+// GitLabError is used for unmarshaling JSON errors from GitLab. The message
+// is either a string or an object with messages per field.
+type GitLabError struct {
+	Message json.RawMessage `json:"message"`
+	Err     string          `json:"error"`
+}
+
+// gitLabAPIError is the apiErrorFunc for GitLab
+func gitLabAPIError(b []byte) error {
+	var e GitLabError
+	if err := json.Unmarshal(b, &e); err != nil || (len(e.Message) == 0 && e.Err == "") {
+		return nil
+	}
+	return e
+}
+
+func (e GitLabError) Error() string {
+	if len(e.Message) == 0 {
+		return e.Err
+	}
+
+	var message string
+	if err := json.Unmarshal(e.Message, &message); err == nil {
+		return message
+	}
+
+	var fields map[string][]string
+	if err := json.Unmarshal(e.Message, &fields); err != nil {
+		return string(e.Message)
+	}
+	var buf bytes.Buffer
+	for field, messages := range fields {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "%s: %s", field, strings.Join(messages, ", "))
+	}
+	return buf.String()
+}
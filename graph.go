@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+)
+
+// graphLine is a line of git log --graph output, lines only drawing the
+// graph have no commit
+type graphLine struct {
+	graph   string
+	id      CommitID
+	message string
+}
+
+// gitLogGraph runs git log --graph for the commits selected by the options
+// and args. The graph is coloured as by git when colour is enabled.
+func gitLogGraph(opts logOptions, args []string) ([]graphLine, error) {
+	color := "--color=never"
+	if colorEnabled {
+		color = "--color=always"
+	}
+	gitArgs := append([]string{"--graph", color}, args...)
+
+	// The NUL separators can not occur in the graph or the subject
+	cmd := exec.Command("git", opts.gitArgs("%x00%H%x00%s", gitArgs)...)
+	debug.Printf("Git log: %q", cmd.Args)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []graphLine
+	for _, line := range strings.Split(strings.TrimSuffix(string(output), "\n"), "\n") {
+		parts := strings.SplitN(line, "\x00", 3)
+		if len(parts) != 3 {
+			lines = append(lines, graphLine{graph: line})
+			continue
+		}
+		lines = append(lines, graphLine{graph: parts[0], id: CommitID(parts[1]), message: parts[2]})
+	}
+	return lines, nil
+}
+
+// displayLogGraph displays the git log graph with a build badge next to
+// each commit. The statistics are fetched with one BuildStats call.
+func (s *subcommand) displayLogGraph() int {
+	lines, err := gitLogGraph(s.log, s.args)
+	logFatalOnError(err)
+
+	var commits CommitIDs
+	for _, line := range lines {
+		if line.id != "" {
+			commits = append(commits, line.id)
+		}
+	}
+	if len(commits) == 0 {
+		return 0
+	}
+
+	stats, err := s.provider.BuildStats(commits)
+	if perr, ok := err.(*PartialStatsError); ok {
+		log.Printf("Warning: %v", perr)
+		err = nil
+	}
+	logFatalOnError(err)
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		if line.id == "" {
+			fmt.Fprintln(&buf, line.graph)
+			continue
+		}
+		id := hyperlink(commitURL(s.provider, line.id), colorize("yellow", line.id.abbrevCommit()))
+		fmt.Fprintf(&buf, "%s%s %s %s\n", line.graph, id, badge(stats[line.id]), line.message)
+	}
+	fmt.Print(buf.String())
+	return 0
+}
+
+// badge is a compact form of the build statistics, e.g. ✔3 ●1 ✘0
+func badge(stat BuildStatusCommitStat) string {
+	counts := []struct {
+		state BuildState
+		count int
+	}{
+		{BuildStateSuccessful, stat.Successful},
+		{BuildStateInProgress, stat.InProgress},
+		{BuildStateFailed, stat.Failed},
+	}
+
+	var parts []string
+	for _, c := range counts {
+		part := fmt.Sprintf("%s%d", glyph(c.state), c.count)
+		if c.count > 0 {
+			part = colorize(c.state, part)
+		} else {
+			part = colorize("dim", part)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
@@ -6,16 +6,19 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
 	"os"
 	"os/exec"
 	"path"
 	"runtime"
+	"strconv"
 	"strings"
-	"text/template"
+	"time"
 
 	"golang.org/x/crypto/ssh/terminal"
 )
@@ -23,6 +26,15 @@ import (
 //go:generate go run tools/include.go
 var (
 	debug = debugger{log.New(ioutil.Discard, " * ", 0)}
+	stdin = bufio.NewReader(os.Stdin)
+
+	// fatalExitCode is the exit code of fatal errors, with -check and -wait
+	// it is exitError so errors are not taken for failed builds
+	fatalExitCode = 1
+
+	// processStart is when the process started, -prompt keeps its time
+	// budget from here
+	processStart = time.Now()
 )
 
 // TODO(nils): document build-state.format.log and build-state.format.state
@@ -38,6 +50,18 @@ Date:  {{.DateAdded}}
 	buildStatusDefaultTemplate = `{{.ID}} {{.Message}}
    Successful: {{.Status.Successful}}, In Progress: {{.Status.InProgress}}, Failed: {{.Status.Failed}}
 `
+
+	// The colour templates are used by default when colour is enabled
+	buildStateColorTemplate = `Name:  {{color "bold" .Name}}     Key: {{.Key}}
+State: {{color .State (glyph .State) " " .State}}
+URL:   {{link .URL .URL}}
+Date:  {{.DateAdded}}
+
+   {{.Description}}
+`
+	buildStatusColorTemplate = `{{link .CommitURL (color "yellow" .ID)}} {{.Message}}
+   {{color "SUCCESSFUL" (glyph "SUCCESSFUL") " Successful: " .Status.Successful}}, {{color "INPROGRESS" (glyph "INPROGRESS") " In Progress: " .Status.InProgress}}, {{color "FAILED" (glyph "FAILED") " Failed: " .Status.Failed}}
+`
 )
 
 type debugger struct {
@@ -63,17 +87,56 @@ func main() {
 		debugFlag            = flag.Bool("debug", false, "Enable debug output")
 		format               = flag.String("format", "", "Go text template, see manual for more info")
 		formatJSON           = flag.Bool("json", false, "Format output as JSON")
+		remote               = flag.String("remote", "", "The git remote to use, defaults to the upstream of the current branch")
+		pageSize             = flag.Int("page-size", 0, "Number of build statuses to request per page")
+		setFlag              = flag.Bool("set", false, "Set the build status of a commit")
+		key                  = flag.String("key", "", "Build key used with -set")
+		state                = flag.String("state", "", "Build state used with -set: INPROGRESS, SUCCESSFUL or FAILED")
+		buildURL             = flag.String("url", "", "Build URL used with -set")
+		name                 = flag.String("name", "", "Build name used with -set")
+		description          = flag.String("description", "", "Build description used with -set")
+		checkFlag            = flag.Bool("check", false, "Print a summary and exit with a code reflecting the build state")
+		waitFlag             = flag.Bool("wait", false, "Wait until no build is in progress")
+		timeout              = flag.Duration("timeout", 30*time.Minute, "Maximum time to wait, used with -wait")
+		interval             = flag.Duration("interval", 15*time.Second, "Initial poll interval, used with -wait")
+		maxCount             = flag.Int("n", 8, "Number of commits to display, used with -log")
+		firstParent          = flag.Bool("first-parent", false, "Follow only the first parent of merge commits, used with -log")
+		since                = flag.String("since", "", "Show commits more recent than a date, used with -log")
+		until                = flag.String("until", "", "Show commits older than a date, used with -log")
+		author               = flag.String("author", "", "Show commits by matching authors, used with -log")
+		graph                = flag.Bool("graph", false, "Draw the commit graph with build badges, used with -log")
+		noCache              = flag.Bool("no-cache", false, "Do not read or write the build state cache")
+		refresh              = flag.Bool("refresh", false, "Fetch build states even if cached")
+		colorFlag            = flag.String("color", colorAuto, "Colour output: auto, always or never")
+		promptFlag           = flag.Bool("prompt", false, "Print a short build state token of HEAD for shell prompts")
+		promptRefreshFlag    = flag.Bool("prompt-refresh", false, "Refresh the prompt state, used internally by -prompt")
 	)
 	flag.Parse()
 
+	if *checkFlag || *waitFlag {
+		fatalExitCode = exitError
+	}
+
 	if *debugFlag {
 		debug.SetFlags(0)
 		debug.SetPrefix("==> ")
 		debug.SetOutput(os.Stderr)
 	}
 
+	// The prompt is printed without setting up a provider, as it must not
+	// block the shell. Its output is captured by the shell and not coloured
+	// unless asked for, so git is not asked whether to colour it.
+	if *promptFlag {
+		if *colorFlag == colorAlways {
+			colorEnabled = true
+		}
+		os.Exit(prompt(*format))
+	}
+	logFatalOnError(setupColor(*colorFlag))
+
+	// The prompt refresh sets up the provider itself, to record errors
 	init := true
-	if *generateB64CredsFlag || *installFlag {
+	if *generateB64CredsFlag || *installFlag || *promptRefreshFlag {
 		init = false
 	}
 
@@ -82,28 +145,86 @@ func main() {
 		proto:      *proto,
 		format:     *format,
 		formatJSON: *formatJSON,
+		pageSize:   *pageSize,
+		remote:     *remote,
+		timeout:    *timeout,
+		interval:   *interval,
+		args:       commandArgs(),
+		noCache:    *noCache,
+		// -check and -wait gate on the current state on the server, cached
+		// states are only stored
+		refresh: *refresh || *checkFlag || *waitFlag,
+		log: logOptions{
+			maxCount:    *maxCount,
+			firstParent: *firstParent,
+			since:       *since,
+			until:       *until,
+			author:      *author,
+			graph:       *graph,
+		},
+		buildStatus: BuildStatus{
+			State:       BuildState(*state),
+			Key:         *key,
+			Name:        *name,
+			URL:         *buildURL,
+			Description: *description,
+		},
 	})
 
 	switch {
+	case *promptRefreshFlag:
+		code = subcmd.refreshPrompt(subcmd.ref())
+	case isRunSubcommand(subcmd.args):
+		code = subcmd.run(subcmd.args[1:])
 	case *generateB64CredsFlag:
 		code = subcmd.generateB64Credentials()
 	case *installFlag:
 		code = subcmd.install()
+	case *setFlag:
+		code = subcmd.setBuildState()
+	case *checkFlag && *displayLogFlag:
+		code = subcmd.checkLog()
+	case *checkFlag:
+		code = subcmd.checkBuildState()
+	case *waitFlag:
+		code = subcmd.waitBuildState()
 	case *displayLogFlag:
 		code = subcmd.displayLog()
 	default:
 		code = subcmd.displayBuildState()
 	}
 
+	subcmd.close()
 	os.Exit(code)
 
 }
 
+// defaultBuildStatsConcurrency is the number of concurrent requests used when
+// fetching build statistics commit by commit
+const defaultBuildStatsConcurrency = 4
+
+// defaultBuildStatsChunkSize is the number of commits requested per build
+// statistics request by providers with a batch API
+const defaultBuildStatsChunkSize = 100
+
 type subcommand struct {
-	stashService *StashService
-	proto        string
-	formatJSON   bool
-	format       string
+	provider    Provider
+	apiURL      *url.URL
+	proto       string
+	formatJSON  bool
+	format      string
+	pageSize    int
+	concurrency int
+	chunkSize   int
+	buildStatus BuildStatus
+	timeout     time.Duration
+	interval    time.Duration
+	remote      string
+	repository  Repository
+	args        []string
+	log         logOptions
+	noCache     bool
+	refresh     bool
 }
 
 func newSubcommand(init bool, s subcommand) *subcommand {
@@ -113,26 +234,180 @@ func newSubcommand(init bool, s subcommand) *subcommand {
 		return sub
 	}
 
-	ta := newBasicAuthFromCredentials(mustGitConfig("build-state.auth.user"), mustGitConfig("build-state.auth.credentials"))
-	stashURL, err := stashAPIURL(s.proto)
-	logFatalOnError(err)
-
-	sub.stashService = newStashService(stashURL, ta)
+	logFatalOnError(sub.setup())
 	return sub
 }
 
+// setup resolves the remote and creates the provider from the configuration
+func (s *subcommand) setup() error {
+	// A remote given explicitly must exist, otherwise the build states of
+	// another repository could be used
+	explicit := s.remote != "" || defaultGitConfig("build-state.remote") != ""
+	remote, err := s.resolveRemote()
+	if err != nil {
+		if explicit {
+			return err
+		}
+		debug.Printf("Unable to find git remote: %v", err)
+	}
+
+	if s.pageSize == 0 {
+		if size := defaultGitConfig("build-state.pageSize"); size != "" {
+			if s.pageSize, err = strconv.Atoi(size); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.concurrency = defaultBuildStatsConcurrency
+	if concurrency := defaultGitConfig("build-state.concurrency"); concurrency != "" {
+		if s.concurrency, err = strconv.Atoi(concurrency); err != nil {
+			return err
+		}
+	}
+
+	s.chunkSize = defaultBuildStatsChunkSize
+	if chunkSize := defaultGitConfig("build-state.stats.chunkSize"); chunkSize != "" {
+		if s.chunkSize, err = strconv.Atoi(chunkSize); err != nil {
+			return err
+		}
+	}
+
+	if s.provider, err = s.newProvider(remote); err != nil {
+		return err
+	}
+
+	if !s.noCache {
+		ttl := defaultCacheTTL
+		if t := defaultGitConfig("build-state.cache.ttl"); t != "" {
+			if ttl, err = time.ParseDuration(t); err != nil {
+				return err
+			}
+		}
+		failedTTL := defaultCacheFailedTTL
+		if t := defaultGitConfig("build-state.cache.failedTTL"); t != "" {
+			if failedTTL, err = time.ParseDuration(t); err != nil {
+				return err
+			}
+		}
+		s.provider = newCachedProvider(s.provider, s.cacheKey(), ttl, failedTTL, s.refresh)
+	}
+	return nil
+}
+
+// close releases the provider, such as a running plugin. Errors are only
+// logged as debug output, the work is done.
+func (s *subcommand) close() {
+	if closer, ok := s.provider.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			debug.Printf("Unable to close provider: %v", err)
+		}
+	}
+}
+
+// ref returns the commit given on the command line, empty means HEAD
+func (s *subcommand) ref() string {
+	args := s.args
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// isRunSubcommand reports whether the arguments start the run subcommand. As
+// run always takes flags, run followed by anything else, or nothing, is a
+// commit such as a branch named run.
+func isRunSubcommand(args []string) bool {
+	return len(args) > 1 && args[0] == "run" && strings.HasPrefix(args[1], "-")
+}
+
+// commandArgs returns the arguments after the flags. The flag package drops
+// the -- ending the flags, it is kept so git log tells paths from revisions.
+func commandArgs() []string {
+	args := flag.Args()
+	if i := len(os.Args) - len(args) - 1; i > 0 && os.Args[i] == "--" {
+		return append([]string{"--"}, args...)
+	}
+	return args
+}
+
+// cacheKey returns the key the cache is grouped by, the API host or for
+// plugins the host of the remote. Bitbucket Server keeps build states per
+// commit, the other providers per repository, so forks sharing commits are
+// kept apart by the escaped repository path.
+func (s *subcommand) cacheKey() string {
+	host := s.repository.Host
+	if s.apiURL != nil {
+		host = s.apiURL.Host
+	}
+	if host == "" || providerName(s.repository) == providerBitbucketServer {
+		return host
+	}
+	if path := s.repository.Path(); path != "" {
+		return host + "/" + url.PathEscape(path)
+	}
+	return host
+}
+
+// resolveRemote resolves the remote to use and the repository on the server
+func (s *subcommand) resolveRemote() (string, error) {
+	if s.remote == "" {
+		name, err := gitRemoteName()
+		if err != nil {
+			return "", err
+		}
+		s.remote = name
+	}
+
+	remote, err := gitRemote(s.remote)
+	if err != nil {
+		if werr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("Unable to get the URL of git remote %s, error: %s\n%s", s.remote, err, bytes.TrimSpace(werr.Stderr))
+		}
+		return "", fmt.Errorf("Unable to get the URL of git remote %s, error: %s", s.remote, err)
+	}
+	debug.Printf("Git remote: %s %s", s.remote, remote)
+
+	remoteURL, err := parseRemoteURL(remote)
+	if err != nil {
+		debug.Printf("Unable to derive repository: %v", err)
+		return remote, nil
+	}
+	s.repository = newRepository(s.remote, remoteURL)
+	debug.Printf("Repository: %s", s.repository.Path())
+	return remote, nil
+}
+
 func (s *subcommand) generateB64Credentials() int {
-	_, _, b64credentials := readUserAndPassword()
-	fmt.Printf("git config --global build-state.auth.credentials %s\n", b64credentials)
+	authType := readAuthType()
+	fmt.Printf("git config --global build-state.auth.type %s\n", authType)
+
+	switch authType {
+	case authTypeCredential:
+		// Nothing to store, the credentials are provided by git credential
+		// helpers
+	case authTypeToken:
+		token := readToken()
+		fmt.Printf("git config --global build-state.auth.token %s\n", token)
+	default:
+		_, _, b64credentials := readUserAndPassword()
+		fmt.Printf("git config --global build-state.auth.credentials %s\n", b64credentials)
+	}
 	return 0
 }
 
-func readUserAndPassword() (user, password, b64credentials string) {
-	reader := bufio.NewReader(os.Stdin)
-
-	fmt.Print("Username: ")
-	user, err := reader.ReadString('\n')
+func readLine(prompt string) string {
+	fmt.Print(prompt)
+	line, err := stdin.ReadString('\n')
 	logFatalOnError(err)
+	return strings.TrimSpace(line)
+}
+
+func readUserAndPassword() (user, password, b64credentials string) {
+	user = readLine("Username: ")
 
 	fmt.Print("Password: ")
 	passwd, err := terminal.ReadPassword(int(os.Stdin.Fd()))
@@ -141,40 +416,52 @@ func readUserAndPassword() (user, password, b64credentials string) {
 	// Print a new line as the we did not echo when reading the password
 	fmt.Println("")
 
-	user = strings.TrimSpace(user)
 	passwd = bytes.TrimSpace(passwd)
 	ta := newBasicAuth(user, string(passwd))
 	return user, string(passwd), ta.b64credentials
 }
 
+// logView is the data log templates are executed with, prompt templates get
+// the commit and its build statistics
+type logView struct {
+	ID         CommitID
+	Message    string
+	Status     BuildStatusCommitStat
+	Repository Repository `json:"-"`
+	CommitURL  string     `json:"-"`
+}
+
 func (s *subcommand) displayLog() int {
+	if s.log.graph && !s.formatJSON {
+		return s.displayLogGraph()
+	}
+
 	var tmp []interface{}
 
-	logs, err := gitLogShort(flag.Arg(0))
+	logs, err := gitLogShort(s.log, s.args)
 	logFatalOnError(err)
 
-	bs, err := s.stashService.BuildStats(logs)
+	bs, err := s.provider.BuildStats(logs)
+	if perr, ok := err.(*PartialStatsError); ok {
+		log.Printf("Warning: %v", perr)
+		err = nil
+	}
 	logFatalOnError(err)
 
-	if s.format == "" {
-		s.format = buildStatusDefaultTemplate
-		if f := defaultGitConfig("build-state.format.log"); f != "" {
-			s.format = f
-		}
-	}
+	format, err := formatTemplate(s.format, formatViewLog)
+	logFatalOnError(err)
+	debug.Printf("Format: %q", format)
 
-	t, err := template.New("BuildState").Parse(s.format)
+	t, err := newTemplate("BuildState", format)
 	logFatalOnError(err)
 
 	for _, log := range logs {
-		bsl := struct {
-			ID      CommitID
-			Message string
-			Status  BuildStatusCommitStat
-		}{
-			ID:      log.id,
-			Message: log.message,
-			Status:  bs[log.id],
+		bsl := logView{
+			ID:         log.id,
+			Message:    log.message,
+			Status:     bs[log.id],
+			Repository: s.repository,
+			CommitURL:  commitURL(s.provider, log.id),
 		}
 		if s.formatJSON {
 			tmp = append(tmp, bsl)
@@ -193,38 +480,82 @@ func (s *subcommand) displayLog() int {
 }
 
 func (s *subcommand) displayBuildState() int {
-	if s.format == "" {
-		s.format = buildStateDefaultTemplate
-		if f := defaultGitConfig("build-state.format.state"); f != "" {
-			s.format = f
-		}
-	}
-	debug.Printf("Format: %q", s.format)
-	debug.Printf("Git ref: %s", flag.Arg(0))
-
-	commit, err := newCommitIDFromRef(flag.Arg(0))
-	if err != nil {
-		if werr, ok := err.(*exec.ExitError); ok {
-			log.Printf("%s", werr.Stderr)
-		}
-		log.Fatalf("Not a valid git reference: %s, error: %s", flag.Arg(0), err)
-	}
+	debug.Printf("Git ref: %s", s.ref())
 
+	commit := mustCommitIDFromRef(s.ref())
 	debug.Printf("Git commit: %s", commit)
-	bs, err := s.stashService.BuildStatus(commit)
+	bs, err := s.provider.BuildStatus(commit)
+	logFatalOnError(err)
+
+	s.printBuildState(commit, bs)
+	return 0
+}
+
+func (s *subcommand) printBuildState(commit CommitID, bs BuildStatusResponse) {
+	format, err := formatTemplate(s.format, formatViewState)
 	logFatalOnError(err)
+	debug.Printf("Format: %q", format)
+	bs.Repository = s.repository
+	bs.Commit = commit
+	bs.CommitURL = commitURL(s.provider, commit)
 
 	if s.formatJSON {
 		out, err := json.MarshalIndent(bs.Values, "", "   ")
 		logFatalOnError(err)
 		fmt.Printf("%s\n", out)
-		return 0
+		return
+	}
+
+	fmt.Print(bs.Format(format))
+}
+
+func (s *subcommand) setBuildState() int {
+	bs := s.buildStatus
+	state, err := parseBuildState(string(bs.State))
+	if err != nil {
+		log.Printf("%v", err)
+		return 1
+	}
+	bs.State = state
+
+	if bs.Key == "" || bs.URL == "" {
+		log.Printf("Both -key and -url are required when setting the build state")
+		return 1
 	}
 
-	fmt.Print(bs.Format(s.format))
+	commit := mustCommitIDFromRef(s.ref())
+	debug.Printf("Git commit: %s", commit)
+	if err := s.provider.SetBuildStatus(commit, bs); err != nil {
+		log.Printf("Unable to set build state for %s:\n%v", commit, err)
+		return 1
+	}
 	return 0
 }
 
+// mustCommitIDFromRef resolves the git reference to a commit. An invalid
+// reference will terminate the execution.
+func mustCommitIDFromRef(ref string) CommitID {
+	commit, err := commitIDFromRef(ref)
+	if err != nil {
+		log.Printf("%v", err)
+		os.Exit(fatalExitCode)
+	}
+	return commit
+}
+
+// commitIDFromRef resolves the git reference to a commit, the error includes
+// the output of git
+func commitIDFromRef(ref string) (CommitID, error) {
+	commit, err := newCommitIDFromRef(ref)
+	if err != nil {
+		if werr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("Not a valid git reference: %s, error: %s\n%s", ref, err, bytes.TrimSpace(werr.Stderr))
+		}
+		return "", fmt.Errorf("Not a valid git reference: %s, error: %s", ref, err)
+	}
+	return commit, nil
+}
+
 func exists(path string) bool {
 	_, err := os.Stat(path)
 	if err == nil {
@@ -290,6 +621,22 @@ func (s *subcommand) install() int {
 
 	// Configure user
 	fmt.Println("\nConfiguring Stash/Bitbucket credentials (abort with ctrl-c)")
+	authType := readAuthType()
+	fmt.Printf("Setting: %s=%s\n", "build-state.auth.type", authType)
+	logFatalOnError(setGitConfig("build-state.auth.type", authType))
+
+	switch authType {
+	case authTypeCredential:
+		fmt.Println("Credentials will be asked for by git credential helpers on first use, see gitcredentials(7)")
+		return 0
+	case authTypeToken:
+		fmt.Println("Access token will be saved in global git config")
+		token := readToken()
+		fmt.Printf("Setting: %s=%s\n", "build-state.auth.token", "**********")
+		logFatalOnError(setGitConfig("build-state.auth.token", token))
+		return 0
+	}
+
 	fmt.Println("Base64 encoded password will be saved in global git config")
 	user, _, b64credentials := readUserAndPassword()
 	fmt.Printf("Setting: %s=%s\n", "build-state.auth.user", user)
@@ -332,5 +679,6 @@ func logFatalOnError(err error) {
 		log.Printf("%s:%d: %s", file, line, werr.Stderr)
 	}
 
-	log.Fatalf("%s:%d: %v\n", file, line, err)
+	log.Printf("%s:%d: %v\n", file, line, err)
+	os.Exit(fatalExitCode)
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// netrcEntry is a machine entry in a .netrc file
+type netrcEntry struct {
+	machine  string
+	login    string
+	password string
+}
+
+// netrcPath returns the path to the .netrc file, $NETRC takes precedence over
+// the one in the home directory
+func netrcPath() string {
+	if p := os.Getenv("NETRC"); p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".netrc")
+}
+
+// netrcLookup returns the entry for host in the .netrc file. The default
+// entry is used when no machine matches.
+func netrcLookup(host string) (netrcEntry, bool) {
+	p := netrcPath()
+	if p == "" {
+		return netrcEntry{}, false
+	}
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			debug.Printf("Unable to read %s: %v", p, err)
+		}
+		return netrcEntry{}, false
+	}
+
+	var def *netrcEntry
+	for _, entry := range parseNetrc(string(b)) {
+		if entry.machine == host {
+			return entry, true
+		}
+		if entry.machine == "" && def == nil {
+			e := entry
+			def = &e
+		}
+	}
+	if def != nil {
+		return *def, true
+	}
+	return netrcEntry{}, false
+}
+
+// parseNetrc parses the machine and default entries of a .netrc file. Macro
+// definitions are skipped.
+func parseNetrc(data string) []netrcEntry {
+	var (
+		entries []netrcEntry
+		entry   *netrcEntry
+	)
+
+	lines := strings.Split(data, "\n")
+	for i := 0; i < len(lines); i++ {
+		fields := strings.Fields(lines[i])
+		for j := 0; j < len(fields); j++ {
+			next := func() string {
+				if j+1 < len(fields) {
+					j++
+					return fields[j]
+				}
+				return ""
+			}
+
+			switch fields[j] {
+			case "machine":
+				entries = append(entries, netrcEntry{machine: next()})
+				entry = &entries[len(entries)-1]
+			case "default":
+				entries = append(entries, netrcEntry{})
+				entry = &entries[len(entries)-1]
+			case "login":
+				if v := next(); entry != nil {
+					entry.login = v
+				}
+			case "password":
+				if v := next(); entry != nil {
+					entry.password = v
+				}
+			case "account":
+				next()
+			case "macdef":
+				// The macro lasts until the next empty line
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+				j = len(fields)
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// pluginPrefix is the prefix of external provider executables, the provider
+// foo is implemented by git-build-state-provider-foo
+const pluginPrefix = "git-build-state-provider-"
+
+// PluginService implements the Provider interface by delegating to an
+// external executable. As for git remote helpers, the executable is started
+// once and receives one JSON request per line on stdin, and answers each
+// with one JSON value on stdout.
+type PluginService struct {
+	path       string
+	repository Repository
+
+	// timeout limits the time the plugin may take to answer a request, zero
+	// means no timeout
+	timeout time.Duration
+
+	mu  sync.Mutex
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	out *json.Decoder
+}
+
+// pluginRequest is sent to the plugin, op is one of status, stats and set
+type pluginRequest struct {
+	Op         string       `json:"op"`
+	Repository Repository   `json:"repository"`
+	Commit     CommitID     `json:"commit,omitempty"`
+	Commits    CommitIDs    `json:"commits,omitempty"`
+	Status     *BuildStatus `json:"status,omitempty"`
+}
+
+// newPluginService looks up the executable for the provider in PATH. The
+// timeout is build-state.plugin.timeout, which defaults to
+// build-state.http.timeout.
+func newPluginService(name string, repo Repository) (*PluginService, error) {
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown build-state.provider: %q, no built-in provider and %s%s not found in PATH", name, pluginPrefix, name)
+	}
+
+	timeout, err := durationGitConfig("build-state.http.timeout", defaultHTTPTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if timeout, err = durationGitConfig("build-state.plugin.timeout", timeout); err != nil {
+		return nil, err
+	}
+	debug.Printf("Provider plugin: %s, timeout: %s", path, timeout)
+
+	return &PluginService{
+		path:       path,
+		repository: repo,
+		timeout:    timeout,
+	}, nil
+}
+
+func (s *PluginService) start() error {
+	cmd := exec.Command(s.path)
+	cmd.Stderr = os.Stderr
+
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	s.cmd, s.in, s.out = cmd, in, json.NewDecoder(out)
+	return nil
+}
+
+// call sends the request and decodes the response into v. Responses holding
+// errors, on the same form as Stash/Bitbucket errors, are returned as errors.
+func (s *PluginService) call(req pluginRequest, v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cmd == nil {
+		if err := s.start(); err != nil {
+			return fmt.Errorf("unable to start %s: %v", s.path, err)
+		}
+	}
+
+	req.Repository = s.repository
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	debug.Printf("Plugin request: %s", b)
+
+	// The plugin may hang, the request is sent and the response read in the
+	// background so the plugin can be killed once the timeout expires
+	in, out := s.in, s.out
+	var res json.RawMessage
+	done := make(chan error, 1)
+	go func() {
+		if _, err := in.Write(append(b, '\n')); err != nil {
+			done <- fmt.Errorf("unable to send request to %s: %v", s.path, err)
+			return
+		}
+		if err := out.Decode(&res); err != nil {
+			done <- fmt.Errorf("unable to read response from %s: %v", s.path, err)
+			return
+		}
+		done <- nil
+	}()
+
+	var timeout <-chan time.Time
+	if s.timeout > 0 {
+		timer := time.NewTimer(s.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			s.kill()
+			return err
+		}
+	case <-timeout:
+		s.kill()
+		return fmt.Errorf("%s did not answer within %s, see build-state.plugin.timeout", s.path, s.timeout)
+	}
+	debug.Printf("Plugin response: %s", res)
+
+	if err := stashAPIError(res); err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(res, v)
+}
+
+// kill ends the plugin, it is started again by the next request
+func (s *PluginService) kill() {
+	s.in.Close()
+	s.cmd.Process.Kill()
+	s.cmd.Wait()
+	s.cmd = nil
+}
+
+// Close closes the standard input of the plugin, which tells it to exit, and
+// waits for it. A plugin still running after the timeout is killed.
+func (s *PluginService) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cmd == nil {
+		return nil
+	}
+	cmd := s.cmd
+	s.cmd = nil
+	s.in.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var timeout <-chan time.Time
+	if s.timeout > 0 {
+		timer := time.NewTimer(s.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-timeout:
+		cmd.Process.Kill()
+		<-done
+		return fmt.Errorf("%s did not exit within %s and was killed", s.path, s.timeout)
+	}
+}
+
+// BuildStatus asks the plugin for the build statuses of the commit
+func (s *PluginService) BuildStatus(c CommitID) (BuildStatusResponse, error) {
+	var bs BuildStatusResponse
+	err := s.call(pluginRequest{Op: "status", Commit: c}, &bs)
+	return bs, err
+}
+
+// BuildStats asks the plugin for the build statistics of the commits
+func (s *PluginService) BuildStats(c CommitIDer) (BuildStatusCommitStats, error) {
+	var stats BuildStatusCommitStats
+	err := s.call(pluginRequest{Op: "stats", Commits: c.CommitIDs()}, &stats)
+	return stats, err
+}
+
+// SetBuildStatus asks the plugin to associate the build status with the
+// commit
+func (s *PluginService) SetBuildStatus(c CommitID, bs BuildStatus) error {
+	return s.call(pluginRequest{Op: "set", Commit: c, Status: &bs}, nil)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultPromptTimeout is how long -prompt may take
+	defaultPromptTimeout = 100 * time.Millisecond
+
+	// promptRefreshInterval is how old the prompt state may be before it is
+	// refreshed, terminal states are mostly served from the cache
+	promptRefreshInterval = 15 * time.Second
+
+	// promptErrorBackoff is how long refreshes are not retried after one
+	// failed, e.g. in repositories without a service
+	promptErrorBackoff = time.Minute
+
+	// promptLockTimeout is how long a refresh may hold the lock, older locks
+	// are left behind by refreshes that died
+	promptLockTimeout = time.Minute
+)
+
+// promptState is the last known build state of HEAD in a repository
+type promptState struct {
+	Commit  CommitID              `json:"commit"`
+	Stat    BuildStatusCommitStat `json:"stat"`
+	Error   string                `json:"error,omitempty"`
+	Updated time.Time             `json:"updated"`
+}
+
+// promptStatePath returns the state file of the repository, keyed by its git
+// directory
+func promptStatePath(gitDir string) string {
+	dir := cacheDir()
+	if dir == "" {
+		return ""
+	}
+	sum := sha1.Sum([]byte(gitDir))
+	return filepath.Join(dir, "prompt", hex.EncodeToString(sum[:]))
+}
+
+// gitPromptHead returns the git directory and HEAD, with one git process
+func gitPromptHead() (string, CommitID, error) {
+	output, err := exec.Command("git", "rev-parse", "--absolute-git-dir", "--verify", "-q", "HEAD").Output()
+	if err != nil {
+		return "", "", err
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 2 {
+		return "", "", fmt.Errorf("unexpected output: %q", output)
+	}
+	return lines[0], CommitID(lines[1]), nil
+}
+
+// prompt prints a short token with the build state of HEAD for shell
+// prompts, formatted with the prompt format. The last known state is printed
+// and refreshed in the background, nothing is printed outside of git
+// repositories or when the state is not known within
+// build-state.prompt.timeout of the start of the process. The git commands
+// are run before waiting, so they count against the timeout.
+func prompt(format string) int {
+	budget := defaultPromptTimeout
+	start := processStart
+
+	gitDir, head, err := gitPromptHead()
+	if err != nil {
+		debug.Printf("Not in a git repository with commits: %v", err)
+		return 0
+	}
+	path := promptStatePath(gitDir)
+	if path == "" {
+		return 0
+	}
+
+	if t, err := durationGitConfig("build-state.prompt.timeout", defaultPromptTimeout); err == nil {
+		budget = t
+	}
+	deadline := start.Add(budget)
+
+	tmpl, err := formatTemplate(format, formatViewPrompt)
+	if err != nil {
+		log.Printf("Invalid prompt format: %v", err)
+		return 1
+	}
+	t, err := newTemplate("Prompt", tmpl)
+	if err != nil {
+		log.Printf("Invalid prompt format: %v", err)
+		return 1
+	}
+
+	state, ok := readPromptState(path)
+	failed := ok && state.Error != "" && (state.Commit == head || state.Commit == "") && time.Since(state.Updated) < promptErrorBackoff
+	if failed {
+		debug.Printf("Prompt refresh failed recently: %s", state.Error)
+		return 0
+	}
+	if !ok || state.Commit != head || time.Since(state.Updated) > promptRefreshInterval {
+		startPromptRefresh(path)
+	}
+
+	// Wait for the refresh within the budget when HEAD is not known yet
+	for (!ok || state.Commit != head) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		state, ok = readPromptState(path)
+	}
+	debug.Printf("Prompt state: %+v after %s", state, time.Since(start))
+
+	if !ok || state.Commit != head || state.Error != "" {
+		return 0
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, logView{ID: head, Status: state.Stat}); err != nil {
+		log.Printf("Invalid prompt format: %v", err)
+		return 1
+	}
+	if token := strings.TrimSpace(buf.String()); token != "" {
+		fmt.Println(token)
+	}
+	return 0
+}
+
+func readPromptState(path string) (promptState, bool) {
+	var state promptState
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return state, false
+	}
+	if err := json.Unmarshal(b, &state); err != nil {
+		return state, false
+	}
+	return state, true
+}
+
+// writePromptState replaces the state file atomically
+func writePromptState(path string, state promptState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// startPromptRefresh starts a detached git-build-state -prompt-refresh,
+// unless a refresh is already running
+func startPromptRefresh(path string) {
+	if info, err := os.Stat(path + ".lock"); err == nil && time.Since(info.ModTime()) < promptLockTimeout {
+		debug.Printf("Prompt refresh already running")
+		return
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		debug.Printf("Unable to start prompt refresh: %v", err)
+		return
+	}
+
+	// The refresh is detached from the terminal, which it must never prompt
+	// on
+	cmd := exec.Command(self, "-prompt-refresh", path)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		debug.Printf("Unable to start prompt refresh: %v", err)
+		return
+	}
+	debug.Printf("Prompt refresh started: %d", cmd.Process.Pid)
+	cmd.Process.Release()
+}
+
+// refreshPrompt fetches the build state of HEAD and writes the prompt state
+// file, guarded by a lock file so only one refresh runs at the time. Errors,
+// including setting up the provider, are recorded in the state so prompts
+// back off.
+func (s *subcommand) refreshPrompt(path string) int {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		debug.Printf("Unable to refresh prompt: %v", err)
+		return 1
+	}
+
+	lock := path + ".lock"
+	if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) >= promptLockTimeout {
+		os.Remove(lock)
+	}
+	f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		debug.Printf("Prompt refresh already running: %v", err)
+		return 0
+	}
+	f.Close()
+	defer os.Remove(lock)
+
+	var state promptState
+	state.Commit, err = commitIDFromRef("HEAD")
+	if err == nil {
+		err = s.setup()
+	}
+	if err == nil {
+		var bs BuildStatusResponse
+		bs, err = s.provider.BuildStatus(state.Commit)
+		state.Stat = bs.Stat()
+		s.close()
+	}
+	if err != nil {
+		debug.Printf("Unable to refresh prompt: %v", err)
+		state.Error = err.Error()
+	}
+	state.Updated = time.Now()
+
+	if err := writePromptState(path, state); err != nil {
+		debug.Printf("Unable to refresh prompt: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Provider is implemented by the services build states are read from and
+// written to
+type Provider interface {
+	// BuildStatus returns all build statuses of the commit
+	BuildStatus(CommitID) (BuildStatusResponse, error)
+
+	// BuildStats returns build statistics for the commits
+	BuildStats(CommitIDer) (BuildStatusCommitStats, error)
+
+	// SetBuildStatus associates a build status with the commit
+	SetBuildStatus(CommitID, BuildStatus) error
+}
+
+// CommitURLer is implemented by providers knowing the web page of commits
+type CommitURLer interface {
+	CommitURL(CommitID) string
+}
+
+// commitURL returns the web page of the commit, or an empty string if the
+// provider does not know it
+func commitURL(p Provider, c CommitID) string {
+	if u, ok := p.(CommitURLer); ok {
+		return u.CommitURL(c)
+	}
+	return ""
+}
+
+// webURL returns the web URL of the service from its API URL by removing the
+// API path
+func webURL(api *url.URL, apiPath string) string {
+	web := *api
+	web.Path = strings.TrimSuffix(strings.TrimSuffix(web.Path, "/"), apiPath)
+	web.RawQuery = ""
+	return strings.TrimSuffix(web.String(), "/")
+}
+
+// Provider names for build-state.provider
+const (
+	providerBitbucketServer = "bitbucket-server"
+	providerBitbucketCloud  = "bitbucket-cloud"
+	providerGitHub          = "github"
+	providerGitLab          = "gitlab"
+	providerGitea           = "gitea"
+)
+
+// providerName returns the provider configured with build-state.provider, or
+// the one inferred from the host of the repository
+func providerName(repo Repository) string {
+	switch name := strings.ToLower(defaultGitConfig("build-state.provider")); name {
+	case "stash", "bitbucket":
+		return providerBitbucketServer
+	case "bitbucket.org":
+		return providerBitbucketCloud
+	case "forgejo":
+		return providerGitea
+	case "":
+		break
+	default:
+		return name
+	}
+
+	switch {
+	case repo.Host == "bitbucket.org":
+		return providerBitbucketCloud
+	case isGitHubHost(repo.Host):
+		return providerGitHub
+	case isGitLabHost(repo.Host):
+		return providerGitLab
+	case isGiteaHost(repo.Host):
+		return providerGitea
+	}
+	return providerBitbucketServer
+}
+
+// listOptions controls how the providers list build states
+type listOptions struct {
+	// pageSize is the number of results requested per page, zero lets the
+	// server decide
+	pageSize int
+
+	// concurrency limits the number of concurrent requests
+	concurrency int
+}
+
+// newProvider creates the provider and authenticator for the repository
+func (s *subcommand) newProvider(remote string) (Provider, error) {
+	name := providerName(s.repository)
+	debug.Printf("Provider: %s", name)
+
+	var (
+		apiURL *url.URL
+		err    error
+	)
+	switch name {
+	case providerBitbucketServer:
+		apiURL, err = stashAPIURL(s.proto, remote)
+	case providerBitbucketCloud:
+		apiURL, err = bitbucketCloudAPIURL()
+	case providerGitHub:
+		apiURL, err = gitHubAPIURL(s.repository)
+	case providerGitLab:
+		apiURL, err = gitLabAPIURL(s.proto, remote)
+	case providerGitea:
+		apiURL, err = giteaAPIURL(s.proto, remote)
+	default:
+		return newPluginService(name, s.repository)
+	}
+	if err != nil {
+		return nil, err
+	}
+	debug.Printf("API URL: %s", apiURL)
+	s.apiURL = apiURL
+
+	if err := configureHTTP(apiURL); err != nil {
+		return nil, err
+	}
+
+	a, err := newAuthenticator(apiURL, name == providerBitbucketServer)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := listOptions{pageSize: s.pageSize, concurrency: s.concurrency}
+	switch name {
+	case providerBitbucketCloud:
+		return newBitbucketCloudService(apiURL, a, s.repository, opts), nil
+	case providerGitHub:
+		return newGitHubService(apiURL, a, s.repository, opts), nil
+	case providerGitLab:
+		return newGitLabService(apiURL, a, s.repository, opts), nil
+	case providerGitea:
+		return newGiteaService(apiURL, a, s.repository, opts), nil
+	default:
+		return newStashService(apiURL, a, s.repository, opts, s.chunkSize), nil
+	}
+}
+
+// collectBuildStats builds the statistics for each commit from its build
+// statuses, for providers without a batch API. At most concurrency requests
+// are made at the same time.
+func collectBuildStats(c CommitIDer, concurrency int, buildStatus func(CommitID) (BuildStatusResponse, error)) (BuildStatusCommitStats, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	type result struct {
+		commit CommitID
+		stat   BuildStatusCommitStat
+		err    error
+	}
+
+	commits := c.CommitIDs()
+	jobs := make(chan CommitID)
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(commits); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for commit := range jobs {
+				bs, err := buildStatus(commit)
+				results <- result{commit: commit, stat: bs.Stat(), err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for _, commit := range commits {
+			jobs <- commit
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	var err error
+	stats := BuildStatusCommitStats{}
+	for r := range results {
+		if r.err != nil {
+			if err == nil {
+				err = r.err
+			}
+			continue
+		}
+		stats[r.commit] = r.stat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// parseRemoteURL parses a git remote URL. Besides URLs with a scheme, such as
+// ssh://git@host:7999/proj/repo.git, the scp-like syntax
+// [user@]host:proj/repo.git is supported.
+func parseRemoteURL(remote string) (*url.URL, error) {
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse remote: %v", err)
+		}
+		return u, nil
+	}
+
+	// The scp-like syntax has a colon before the first slash, anything else is
+	// a local path
+	colon := strings.Index(remote, ":")
+	if colon < 1 || strings.Contains(remote[:colon], "/") {
+		return nil, fmt.Errorf("Unable to parse remote: %s is not a URL", remote)
+	}
+
+	u := &url.URL{
+		Scheme: "ssh",
+		Host:   remote[:colon],
+		Path:   "/" + strings.TrimPrefix(remote[colon+1:], "/"),
+	}
+	if at := strings.LastIndex(u.Host, "@"); at != -1 {
+		u.User = url.User(u.Host[:at])
+		u.Host = u.Host[at+1:]
+	}
+	return u, nil
+}
+
+// Repository identifies the repository on the server, derived from the
+// remote URL
+type Repository struct {
+	Remote  string `json:"remote"`
+	Host    string `json:"host"`
+	Project string `json:"project"`
+	Slug    string `json:"slug"`
+}
+
+// Path returns the repository path on the server, project and slug joined
+func (r Repository) Path() string {
+	if r.Project == "" {
+		return r.Slug
+	}
+	return r.Project + "/" + r.Slug
+}
+
+// newRepository derives the project key and repository slug from the remote
+// URL. Context paths up to /scm/ of HTTP remotes are skipped.
+func newRepository(name string, remote *url.URL) Repository {
+	p := remote.Path
+	if i := strings.Index(p, "/scm/"); i != -1 {
+		p = p[i+len("/scm/"):]
+	}
+	p = strings.TrimSuffix(strings.Trim(p, "/"), ".git")
+
+	repo := Repository{
+		Remote: name,
+		Host:   remote.Hostname(),
+		Slug:   p,
+	}
+	if i := strings.LastIndex(p, "/"); i != -1 {
+		repo.Project = p[:i]
+		repo.Slug = p[i+1:]
+	}
+	return repo
+}
+
+// apiURLFromRemote derives the HTTP API URL from the remote URL. HTTP remotes
+// keep their scheme, port and any context path before /scm/. For other
+// remotes only the host is kept, the proto and port are used instead.
+func apiURLFromRemote(remote *url.URL, proto, port string) *url.URL {
+	api := &url.URL{
+		Scheme: proto,
+		Host:   remote.Hostname(),
+	}
+
+	if remote.Scheme == "http" || remote.Scheme == "https" {
+		api.Scheme = remote.Scheme
+		api.Host = remote.Host
+		if i := strings.Index(remote.Path, "/scm/"); i != -1 {
+			api.Path = remote.Path[:i]
+		}
+	}
+
+	if port != "" {
+		api.Host = remote.Hostname() + ":" + port
+	}
+	return api
+}
+
+// remoteBaseURL returns the URL the API URL of the service hosting the remote
+// is derived from. A matching build-state.url.<base>.insteadOf rule gives its
+// base, otherwise the URL is derived from the remote, see apiURLFromRemote.
+func remoteBaseURL(proto, remote string) (*url.URL, error) {
+	base, ok, err := rewriteRemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		debug.Printf("Remote rewritten to: %s", base)
+		return url.Parse(base)
+	}
+
+	remoteURL, err := parseRemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+	return apiURLFromRemote(remoteURL, proto, defaultGitConfig("build-state.port")), nil
+}
+
+// rewriteRemoteURL applies the build-state.url.<base>.insteadOf rules, mapping
+// remotes to the API base URL. Remotes starting with the value of insteadOf
+// use base as API URL, as for git's url.<base>.insteadOf the longest matching
+// prefix wins.
+func rewriteRemoteURL(remote string) (string, bool, error) {
+	rules, err := gitConfigRegexp(`^build-state\.url\..*\.insteadof$`)
+	if err != nil {
+		return "", false, err
+	}
+
+	var base, prefix string
+	for _, rule := range rules {
+		key, value := rule[0], rule[1]
+		if !strings.HasPrefix(remote, value) || len(value) <= len(prefix) {
+			continue
+		}
+		prefix = value
+		base = strings.TrimSuffix(strings.TrimPrefix(key, "build-state.url."), ".insteadof")
+	}
+
+	if prefix == "" {
+		return "", false, nil
+	}
+	return base, true, nil
+}
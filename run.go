@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// run executes a command and reports its outcome as a build state for HEAD.
+// The exit code of the command is returned.
+func (s *subcommand) run(args []string) int {
+	bs := s.buildStatus
+
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	fs.StringVar(&bs.Key, "key", bs.Key, "Build key")
+	fs.StringVar(&bs.Name, "name", bs.Name, "Build name, defaults to the command")
+	fs.StringVar(&bs.URL, "url", bs.URL, "Build URL, defaults to the Stash/Bitbucket URL")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: git build-state run -key <key> [-name <name>] [-url <url>] -- <command> [<args>...]\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if bs.Key == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	command := strings.Join(fs.Args(), " ")
+	if bs.Name == "" {
+		bs.Name = command
+	}
+	if bs.URL == "" && s.apiURL != nil {
+		bs.URL = s.apiURL.String()
+	}
+
+	// Resolve the commit before running the command, as it might create new
+	// commits
+	commit := mustCommitIDFromRef("HEAD")
+	debug.Printf("Git commit: %s", commit)
+
+	bs.State = BuildStateInProgress
+	bs.Description = fmt.Sprintf("Running: %s", command)
+	s.reportBuildState(commit, bs)
+
+	start := time.Now()
+	code, err := runCommand(fs.Arg(0), fs.Args()[1:]...)
+	duration := time.Since(start).Round(100 * time.Millisecond)
+
+	switch {
+	case err != nil:
+		log.Printf("Unable to run %s: %v", fs.Arg(0), err)
+		bs.State = BuildStateFailed
+		bs.Description = fmt.Sprintf("%s could not be started: %v", command, err)
+	case code == 0:
+		bs.State = BuildStateSuccessful
+		bs.Description = fmt.Sprintf("%s succeeded in %s (exit code %d)", command, duration, code)
+	default:
+		bs.State = BuildStateFailed
+		bs.Description = fmt.Sprintf("%s failed in %s (exit code %d)", command, duration, code)
+	}
+	s.reportBuildState(commit, bs)
+
+	return code
+}
+
+// reportBuildState sets the build state, failures are logged but do not
+// abort the execution.
+func (s *subcommand) reportBuildState(commit CommitID, bs BuildStatus) {
+	debug.Printf("Setting build state %s for %s", bs.State, commit)
+	if err := s.provider.SetBuildStatus(commit, bs); err != nil {
+		log.Printf("Unable to set build state %s for %s:\n%v", bs.State, commit, err)
+	}
+}
+
+// runCommand runs the command with the standard streams of the current
+// process. The returned code is the exit code of the command, or 127 if it
+// could not be started.
+func runCommand(name string, args ...string) (int, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// Interrupts from the terminal are delivered to the command as well, keep
+	// running so the outcome can be reported. Terminations are forwarded.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	if err := cmd.Start(); err != nil {
+		return 127, err
+	}
+
+	go func() {
+		for sig := range signals {
+			if sig == syscall.SIGTERM {
+				cmd.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err := cmd.Wait()
+	if err == nil {
+		return 0, nil
+	}
+
+	if exiterr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exiterr.Sys().(syscall.WaitStatus); ok {
+			if status.Signaled() {
+				return 128 + int(status.Signal()), nil
+			}
+			return status.ExitStatus(), nil
+		}
+	}
+	return 1, err
+}
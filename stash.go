@@ -13,23 +13,41 @@ import (
 	"path"
 	"strconv"
 	"strings"
-	"text/template"
+	"sync"
 	"time"
 )
 
-// StashService holds information regarding the stash service
+// StashService holds information regarding the stash service, it implements
+// the Provider interface for Bitbucket Server
 type StashService struct {
 	url           *url.URL
 	authenticator Authenticator
+	repository    Repository
+	listOptions
+
+	// chunkSize is the number of commits requested per build statistics
+	// request
+	chunkSize int
 }
 
-func newStashService(URL *url.URL, a Authenticator) *StashService {
+func newStashService(URL *url.URL, a Authenticator, repo Repository, opts listOptions, chunkSize int) *StashService {
 	return &StashService{
 		url:           URL,
 		authenticator: a,
+		repository:    repo,
+		listOptions:   opts,
+		chunkSize:     chunkSize,
 	}
 }
 
+// do authenticates and sends the request. The response body is returned for
+// successful requests, otherwise the error reported by Stash/Bitbucket.
+func (s *StashService) do(req *http.Request) ([]byte, error) {
+	req.Header.Set("X-Atlassian-Token", "no-check")
+	_, body, err := doRequest(s.authenticator, req, stashAPIError)
+	return body, err
+}
+
 // Commits lists commits for a repository
 func (s *StashService) Commits(project, repo string) CommitIDs {
 	p := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/commits", project, repo)
@@ -48,13 +66,87 @@ func (s *StashService) Commits(project, repo string) CommitIDs {
 	return CommitIDs{}
 }
 
-// BuildStats lists status given commit ids
+// CommitURL returns the web page of the commit
+func (s *StashService) CommitURL(c CommitID) string {
+	if s.repository.Project == "" || s.repository.Slug == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/projects/%s/repos/%s/commits/%s", strings.TrimSuffix(s.url.String(), "/"), url.PathEscape(s.repository.Project), url.PathEscape(s.repository.Slug), c)
+}
+
+// BuildStats lists status given commit ids. The commits are requested in
+// chunks, at most concurrency at the same time. If only some of the chunks
+// fail, the statistics fetched are returned together with a
+// *PartialStatsError.
 func (s *StashService) BuildStats(c CommitIDer) (BuildStatusCommitStats, error) {
+	chunks := c.CommitIDs().chunks(s.chunkSize)
+	if len(chunks) == 1 {
+		return s.buildStatsChunk(chunks[0])
+	}
+
+	concurrency := s.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	type result struct {
+		commits CommitIDs
+		stats   BuildStatusCommitStats
+		err     error
+	}
+
+	jobs := make(chan CommitIDs)
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(chunks); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				stats, err := s.buildStatsChunk(chunk)
+				results <- result{commits: chunk, stats: stats, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for _, chunk := range chunks {
+			jobs <- chunk
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	partial := &PartialStatsError{}
+	stats := BuildStatusCommitStats{}
+	for r := range results {
+		if r.err != nil {
+			partial.Commits = append(partial.Commits, r.commits...)
+			partial.Errs = append(partial.Errs, r.err)
+			continue
+		}
+		for commit, stat := range r.stats {
+			stats[commit] = stat
+		}
+	}
+
+	switch {
+	case len(partial.Errs) == 0:
+		return stats, nil
+	case len(partial.Errs) == len(chunks):
+		return nil, partial.Errs[0]
+	}
+	debug.Printf("Build statistics failed for %d of %d chunks", len(partial.Errs), len(chunks))
+	return stats, partial
+}
+
+// buildStatsChunk requests the statistics of the commits in one request
+func (s *StashService) buildStatsChunk(commits CommitIDs) (BuildStatusCommitStats, error) {
 	p := "/rest/build-status/1.0/commits/stats"
-	client := &http.Client{}
-	b, err := json.Marshal(c.CommitIDs())
+	b, err := json.Marshal(commits)
 	if err != nil {
-		fmt.Printf("Error: %s", err)
 		return nil, err
 	}
 
@@ -62,22 +154,13 @@ func (s *StashService) BuildStats(c CommitIDer) (BuildStatusCommitStats, error)
 	if err != nil {
 		return nil, err
 	}
-	req = s.authenticator.Auth(req)
-	req.Header.Set("X-Atlassian-Token", "no-check")
 	req.Header.Set("Content-Type", "application/json")
-	debug.DumpRequest(req, true)
-
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
 
-	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
+	// The statistics are only read, the request is safe to retry
+	body, err := s.do(withIdempotent(req))
 	if err != nil {
 		return nil, err
 	}
-	debug.Printf("Response: %s", body)
 
 	var commitStatus BuildStatusCommitStats
 	err = json.Unmarshal(body, &commitStatus)
@@ -88,29 +171,91 @@ func (s *StashService) BuildStats(c CommitIDer) (BuildStatusCommitStats, error)
 	return commitStatus, nil
 }
 
-// BuildStatus provides detailed information regarding the build
+// PartialStatsError is returned together with the statistics fetched when
+// the statistics of some commits could not be fetched
+type PartialStatsError struct {
+	// Commits lists the commits without statistics
+	Commits CommitIDs
+	Errs    []error
+}
+
+func (e *PartialStatsError) Error() string {
+	var msgs []string
+	for _, err := range e.Errs {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("build statistics missing for %d commits: %s", len(e.Commits), strings.Join(msgs, "; "))
+}
+
+// SetBuildStatus associates a build status with the commit
+func (s *StashService) SetBuildStatus(c CommitID, bs BuildStatus) error {
+	p := fmt.Sprintf("/rest/build-status/1.0/commits/%s", c)
+	b, err := json.Marshal(struct {
+		State       BuildState `json:"state"`
+		Key         string     `json:"key"`
+		Name        string     `json:"name,omitempty"`
+		URL         string     `json:"url"`
+		Description string     `json:"description,omitempty"`
+	}{bs.State, bs.Key, bs.Name, bs.URL, bs.Description})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", s.url.String()+p, bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = s.do(req)
+	return err
+}
+
+// BuildStatus provides detailed information regarding the build. All pages
+// are fetched and merged into one response.
 func (s *StashService) BuildStatus(c CommitID) (BuildStatusResponse, error) {
+	var values []BuildStatus
+	start := 0
+	for {
+		page, err := s.buildStatusPage(c, start)
+		if err != nil {
+			return BuildStatusResponse{}, err
+		}
+		values = append(values, page.Values...)
+
+		if page.IsLastPage || page.NextPageStart <= start {
+			break
+		}
+		start = page.NextPageStart
+	}
+
+	return newBuildStatusResponse(values), nil
+}
+
+func (s *StashService) buildStatusPage(c CommitID, start int) (BuildStatusResponse, error) {
 	p := fmt.Sprintf("/rest/build-status/1.0/commits/%s", c)
-	client := &http.Client{}
 
-	req, err := http.NewRequest("GET", s.url.String()+p, nil)
-	logFatalOnError(err)
-	req = s.authenticator.Auth(req)
-	req.Header.Set("X-Atlassian-Token", "no-check")
-	debug.DumpRequest(req, false)
+	query := url.Values{}
+	query.Set("start", strconv.Itoa(start))
+	if s.pageSize > 0 {
+		query.Set("limit", strconv.Itoa(s.pageSize))
+	}
 
-	res, err := client.Do(req)
-	logFatalOnError(err)
-	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
-	logFatalOnError(err)
-	debug.Printf("Response: %s", body)
+	req, err := http.NewRequest("GET", s.url.String()+p+"?"+query.Encode(), nil)
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
+
+	body, err := s.do(req)
+	if err != nil {
+		return BuildStatusResponse{}, err
+	}
 
 	var buildStatus BuildStatusResponse
 	err = json.Unmarshal(body, &buildStatus)
 	if err != nil || buildStatus.Size == nil {
 		debug.Printf("error: %v, size: %#v", err, buildStatus.Size)
-		logFatalOnError(newStashError(body))
+		return BuildStatusResponse{}, newStashError(body)
 	}
 
 	return buildStatus, nil
@@ -138,6 +283,26 @@ func (cid CommitID) abbrevCommit() string {
 // CommitIDs is a list of commits
 type CommitIDs []CommitID
 
+// CommitIDs returns the commits, making CommitIDs a CommitIDer
+func (c CommitIDs) CommitIDs() CommitIDs {
+	return c
+}
+
+// chunks splits the commits into chunks of at most size commits, a size of
+// zero or less keeps all commits in one chunk
+func (c CommitIDs) chunks(size int) []CommitIDs {
+	if size <= 0 || len(c) <= size {
+		return []CommitIDs{c}
+	}
+
+	var chunks []CommitIDs
+	for len(c) > size {
+		chunks = append(chunks, c[:size])
+		c = c[size:]
+	}
+	return append(chunks, c)
+}
+
 // CommitIDer is an interface
 type CommitIDer interface {
 	CommitIDs() CommitIDs
@@ -150,6 +315,16 @@ type BuildStatusCommitStat struct {
 	Failed     int `json:"failed"`
 }
 
+// Total returns the number of builds
+func (bs BuildStatusCommitStat) Total() int {
+	return bs.Successful + bs.InProgress + bs.Failed
+}
+
+// terminal reports if builds have been reported and none is in progress
+func (bs BuildStatusCommitStat) terminal() bool {
+	return bs.Total() > 0 && bs.InProgress == 0
+}
+
 func (bs BuildStatusCommitStat) String() string {
 	return fmt.Sprintf("Successful: %d, In Progress: %d, Failed: %d", bs.Successful, bs.InProgress, bs.Failed)
 }
@@ -160,13 +335,42 @@ type BuildStatusCommitStats map[CommitID]BuildStatusCommitStat
 // BuildState is the state representation
 type BuildState string
 
+// Build states known by Stash/Bitbucket
+const (
+	BuildStateSuccessful BuildState = "SUCCESSFUL"
+	BuildStateInProgress BuildState = "INPROGRESS"
+	BuildStateFailed     BuildState = "FAILED"
+)
+
+func parseBuildState(s string) (BuildState, error) {
+	switch state := BuildState(strings.ToUpper(s)); state {
+	case BuildStateSuccessful, BuildStateInProgress, BuildStateFailed:
+		return state, nil
+	}
+	return "", fmt.Errorf("invalid build state: %q, expected one of %s, %s or %s", s, BuildStateInProgress, BuildStateSuccessful, BuildStateFailed)
+}
+
 // StashTime is used for unmarshaling JSON
 type StashTime struct {
 	time.Time
 }
 
-// UnmarshalJSON decodes string to time.Time
+// UnmarshalJSON decodes string to time.Time. Both epoch milliseconds and
+// quoted ISO-8601 timestamps are accepted.
 func (st *StashTime) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return err
+		}
+		*st = StashTime{t}
+		return nil
+	}
+
 	t, err := strconv.ParseInt(string(b), 10, 64)
 	if err != nil {
 		return err
@@ -188,14 +392,29 @@ type BuildStatus struct {
 
 // Format the output of the BuildStatus
 func (bs BuildStatus) Format(tmpl string) string {
+	return bs.format(tmpl, buildStatusView{})
+}
+
+// format executes the template with the build status and the commit and
+// repository it belongs to
+func (bs BuildStatus) format(tmpl string, view buildStatusView) string {
 	var buf bytes.Buffer
-	t, err := template.New("BuildState").Parse(tmpl)
+	t, err := newTemplate("BuildState", tmpl)
 	logFatalOnError(err)
-	err = t.Execute(&buf, bs)
+	view.BuildStatus = bs
+	err = t.Execute(&buf, view)
 	logFatalOnError(err)
 	return buf.String()
 }
 
+// buildStatusView is the data build state templates are executed with
+type buildStatusView struct {
+	BuildStatus
+	Repository Repository
+	Commit     CommitID
+	CommitURL  string
+}
+
 func (bs BuildStatus) String() string {
 	tmpl := `Name:  {{.Name}}     Key: {{.Key}}
 State: {{.State}}
@@ -209,11 +428,28 @@ Date:  {{.Date}}
 
 // BuildStatusResponse represent the JSON response from stash
 type BuildStatusResponse struct {
-	Size       *int          `json:"size"`
-	Limit      int           `json:"limit"`
-	IsLastPage bool          `json:"isLastPage"`
-	Start      int           `json:"start"`
-	Values     []BuildStatus `json:"values"`
+	Size          *int          `json:"size"`
+	Limit         int           `json:"limit"`
+	IsLastPage    bool          `json:"isLastPage"`
+	Start         int           `json:"start"`
+	NextPageStart int           `json:"nextPageStart,omitempty"`
+	Values        []BuildStatus `json:"values"`
+
+	// Repository, Commit and CommitURL are available to templates
+	Repository Repository `json:"-"`
+	Commit     CommitID   `json:"-"`
+	CommitURL  string     `json:"-"`
+}
+
+// newBuildStatusResponse returns a single page response holding all values
+func newBuildStatusResponse(values []BuildStatus) BuildStatusResponse {
+	size := len(values)
+	return BuildStatusResponse{
+		Size:       &size,
+		Limit:      size,
+		IsLastPage: true,
+		Values:     values,
+	}
 }
 
 // Format returns a BuildStatus formated according to tmpl which should
@@ -221,12 +457,32 @@ type BuildStatusResponse struct {
 func (bsr BuildStatusResponse) Format(tmpl string) string {
 	var buf bytes.Buffer
 	for _, value := range bsr.Values {
-		buf.WriteString(value.Format(tmpl))
+		buf.WriteString(value.format(tmpl, buildStatusView{
+			Repository: bsr.Repository,
+			Commit:     bsr.Commit,
+			CommitURL:  bsr.CommitURL,
+		}))
 		buf.Write([]byte("\n"))
 	}
 	return buf.String()
 }
 
+// Stat summarizes the build states of the response
+func (bsr BuildStatusResponse) Stat() BuildStatusCommitStat {
+	var stat BuildStatusCommitStat
+	for _, value := range bsr.Values {
+		switch value.State {
+		case BuildStateSuccessful:
+			stat.Successful++
+		case BuildStateInProgress:
+			stat.InProgress++
+		case BuildStateFailed:
+			stat.Failed++
+		}
+	}
+	return stat
+}
+
 func (bsr BuildStatusResponse) String() string {
 	var buf bytes.Buffer
 	for _, value := range bsr.Values {
@@ -257,6 +513,15 @@ func newStashError(b []byte) error {
 
 	return se
 }
+
+// stashAPIError is the apiErrorFunc for Stash/Bitbucket Server
+func stashAPIError(b []byte) error {
+	if se, ok := newStashError(b).(StashError); ok {
+		return se
+	}
+	return nil
+}
+
 func (se StashError) Error() string {
 	var buf bytes.Buffer
 	for _, err := range se.Errors {
@@ -273,26 +538,60 @@ type Authenticator interface {
 	Auth(*http.Request) *http.Request
 }
 
-// TokenAuth implements the Authenticator interface
+// AuthRejecter is implemented by authenticators that can explain why the
+// server rejected the credentials
+type AuthRejecter interface {
+	Rejected(error) error
+}
+
+// authRejected returns the error to report when the server rejected the
+// credentials of the authenticator
+func authRejected(a Authenticator, err error) error {
+	if ar, ok := a.(AuthRejecter); ok {
+		return ar.Rejected(err)
+	}
+	return err
+}
+
+// AuthApprover is implemented by authenticators that need to know that the
+// server accepted the credentials
+type AuthApprover interface {
+	Approved()
+}
+
+func authApproved(a Authenticator) {
+	if aa, ok := a.(AuthApprover); ok {
+		aa.Approved()
+	}
+}
+
+// TokenAuth is used for personal and HTTP access tokens, sent as bearer
+// tokens
 type TokenAuth struct {
-	user  string
 	token string
+
+	// source tells where the token was found
+	source string
 }
 
-func newTokenAuth(user, token string) *TokenAuth {
+func newTokenAuth(token, source string) *TokenAuth {
 	return &TokenAuth{
-		user:  user,
-		token: token,
+		token:  token,
+		source: source,
 	}
 }
 
 // Auth will add authentication headers to the request object
 func (ta *TokenAuth) Auth(r *http.Request) *http.Request {
-	r.Header.Set("X-Auth-User", ta.user)
-	r.Header.Set("X-Auth-Token", ta.token)
+	r.Header.Set("Authorization", "Bearer "+ta.token)
 	return r
 }
 
+// Rejected explains that the access token is the problem
+func (ta *TokenAuth) Rejected(err error) error {
+	return fmt.Errorf("the access token was rejected, check that %s is valid and not expired: %v", ta.source, err)
+}
+
 // BasicAuth is used for username / password authentication
 type BasicAuth struct {
 	user           string
@@ -317,22 +616,13 @@ func (ba *BasicAuth) Auth(r *http.Request) *http.Request {
 	return r
 }
 
-func stashAPIURL(proto string) (*url.URL, error) {
+func stashAPIURL(proto, remote string) (*url.URL, error) {
 	if endpoint := defaultGitConfig("build-state.endpoint"); endpoint != "" {
 		return url.Parse(endpoint)
 	}
-
-	port := defaultGitConfig("build-state.port")
-
-	remote, err := gitRemote()
-	logFatalOnError(err)
-
-	stashURL := remote.Host
-	if i := strings.Index(stashURL, ":"); i != -1 {
-		stashURL = stashURL[:i]
-	}
-	if port != "" {
-		stashURL = stashURL + ":" + port
+	if remote == "" {
+		return nil, fmt.Errorf("Unable to infer the Stash/Bitbucket URL without a git remote, set build-state.endpoint")
 	}
-	return url.Parse(proto + "://" + stashURL)
+
+	return remoteBaseURL(proto, remote)
 }
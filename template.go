@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultAbbrev is the length of abbreviated commits
+const defaultAbbrev = 7
+
+// templateFuncs are the functions available to the format templates. The
+// value operated on is the last argument, so functions can be used in
+// pipelines, e.g. {{.Name | pad 20}}.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"abbrev":   abbrev,
+		"pad":      pad,
+		"truncate": truncate,
+		"color":    colorize,
+		"glyph":    glyph,
+		"link":     hyperlink,
+		"since":    since,
+		"date":     formatDate,
+		"upper":    func(v interface{}) string { return strings.ToUpper(fmt.Sprint(v)) },
+		"lower":    func(v interface{}) string { return strings.ToLower(fmt.Sprint(v)) },
+		"json":     toJSON,
+		"join":     join,
+		"default":  defaultValue,
+	}
+}
+
+// newTemplate parses a format template
+func newTemplate(name, tmpl string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs()).Parse(tmpl)
+}
+
+// abbrev shortens commits, or any text, to seven characters or the length
+// given before the value: {{abbrev .ID}}, {{abbrev 12 .ID}}
+func abbrev(args ...interface{}) (string, error) {
+	n, v, err := lengthAndValue("abbrev", defaultAbbrev, args)
+	if err != nil {
+		return "", err
+	}
+	return truncateRunes(fmt.Sprint(v), n), nil
+}
+
+// pad pads the text with spaces to the width, negative widths pad on the
+// left: {{pad 20 .Name}}, {{pad -5 .Status.Failed}}
+func pad(width int, v interface{}) string {
+	s := fmt.Sprint(v)
+	n := utf8.RuneCountInString(s)
+	switch {
+	case width >= 0 && n < width:
+		return s + strings.Repeat(" ", width-n)
+	case width < 0 && n < -width:
+		return strings.Repeat(" ", -width-n) + s
+	}
+	return s
+}
+
+// truncate shortens the text to at most n characters, ending with … when
+// shortened: {{truncate 40 .Description}}
+func truncate(n int, v interface{}) string {
+	s := fmt.Sprint(v)
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return truncateRunes(s, n-1) + "…"
+}
+
+func truncateRunes(s string, n int) string {
+	if n < 0 {
+		n = 0
+	}
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// lengthAndValue splits the arguments of functions taking an optional
+// length before the value
+func lengthAndValue(name string, def int, args []interface{}) (int, interface{}, error) {
+	switch len(args) {
+	case 1:
+		return def, args[0], nil
+	case 2:
+		n, err := strconv.Atoi(fmt.Sprint(args[0]))
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s: invalid length: %v", name, args[0])
+		}
+		return n, args[1], nil
+	}
+	return 0, nil, fmt.Errorf("%s: expected [length] value, got %d arguments", name, len(args))
+}
+
+// templateTime returns the time of StashTime and time.Time values
+func templateTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case StashTime:
+		return t.Time, nil
+	case *StashTime:
+		return t.Time, nil
+	case time.Time:
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("not a time: %v", v)
+}
+
+// since returns the time relative to now, as git does: {{since .DateAdded}}
+// gives e.g. 3 hours ago
+func since(v interface{}) (string, error) {
+	t, err := templateTime(v)
+	if err != nil || t.IsZero() {
+		return "", err
+	}
+	return relativeTime(time.Since(t)), nil
+}
+
+func relativeTime(d time.Duration) string {
+	if d < 0 {
+		return "in the future"
+	}
+
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"year", 365 * 24 * time.Hour},
+		{"month", 30 * 24 * time.Hour},
+		{"week", 7 * 24 * time.Hour},
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	}
+	for _, u := range units {
+		if n := int(d / u.size); n > 0 {
+			if n == 1 {
+				return fmt.Sprintf("1 %s ago", u.name)
+			}
+			return fmt.Sprintf("%d %ss ago", n, u.name)
+		}
+	}
+	return "just now"
+}
+
+// formatDate formats the time with a Go layout in local time:
+// {{date "2006-01-02 15:04" .DateAdded}}
+func formatDate(layout string, v interface{}) (string, error) {
+	t, err := templateTime(v)
+	if err != nil || t.IsZero() {
+		return "", err
+	}
+	return t.Local().Format(layout), nil
+}
+
+// toJSON encodes the value as JSON: {{json .}}
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// join joins the elements of a list with the separator: {{join ", " .List}}
+func join(sep string, v interface{}) (string, error) {
+	list := reflect.ValueOf(v)
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: not a list: %v", v)
+	}
+
+	var elems []string
+	for i := 0; i < list.Len(); i++ {
+		elems = append(elems, fmt.Sprint(list.Index(i).Interface()))
+	}
+	return strings.Join(elems, sep), nil
+}
+
+// defaultValue returns the default for empty values: {{default "-" .Name}}
+func defaultValue(def, v interface{}) interface{} {
+	if v == nil {
+		return def
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		if rv.Len() == 0 {
+			return def
+		}
+	default:
+		if rv.IsZero() {
+			return def
+		}
+	}
+	return v
+}
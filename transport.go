@@ -0,0 +1,117 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// httpSetting returns the build-state.http.<name> setting, or the GIT_SSL_*
+// environment variable, or git's http.<name> setting for the URL. This way
+// the service is reached as git fetch reaches it, see git-config(1).
+func httpSetting(u *url.URL, name, env string, opts ...string) (string, error) {
+	v, err := gitConfigTyped("build-state.http."+name, opts...)
+	if err != nil || v != "" {
+		return v, err
+	}
+	if env != "" {
+		if v := os.Getenv(env); v != "" {
+			return v, nil
+		}
+	}
+	return gitConfigURLMatch("http."+name, u.String(), opts...)
+}
+
+// tlsConfig returns the TLS configuration for the URL from the sslCAInfo,
+// sslCert, sslKey and sslVerify settings, nil means the defaults
+func tlsConfig(u *url.URL) (*tls.Config, error) {
+	if u.Scheme != "https" {
+		return nil, nil
+	}
+
+	caInfo, err := httpSetting(u, "sslCAInfo", "GIT_SSL_CAINFO", "--type=path")
+	if err != nil {
+		return nil, err
+	}
+	cert, err := httpSetting(u, "sslCert", "GIT_SSL_CERT", "--type=path")
+	if err != nil {
+		return nil, err
+	}
+	key, err := httpSetting(u, "sslKey", "GIT_SSL_KEY", "--type=path")
+	if err != nil {
+		return nil, err
+	}
+	verify, err := httpSetting(u, "sslVerify", "", "--type=bool")
+	if err != nil {
+		return nil, err
+	}
+	if os.Getenv("GIT_SSL_NO_VERIFY") != "" {
+		verify = "false"
+	}
+
+	if caInfo == "" && cert == "" && verify != "false" {
+		return nil, nil
+	}
+
+	config := &tls.Config{}
+	if caInfo != "" {
+		// As with git, the CA bundle replaces the system roots
+		pem, err := ioutil.ReadFile(caInfo)
+		if err != nil {
+			return nil, fmt.Errorf("sslCAInfo: %v", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("sslCAInfo: no certificates found in %s", caInfo)
+		}
+		debug.Printf("TLS CA bundle: %s", caInfo)
+	}
+
+	if cert != "" {
+		// The key is commonly stored together with the certificate
+		if key == "" {
+			key = cert
+		}
+		c, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("sslCert: %v", err)
+		}
+		config.Certificates = []tls.Certificate{c}
+		debug.Printf("TLS client certificate: %s, key: %s", cert, key)
+	}
+
+	if verify == "false" {
+		debug.Printf("TLS certificate verification disabled")
+		config.InsecureSkipVerify = true
+	}
+	return config, nil
+}
+
+// proxyFunc returns the proxy for the URL from the proxy setting, written as
+// [protocol://][user[:password]@]host[:port], or from the HTTPS_PROXY,
+// HTTP_PROXY and NO_PROXY environment variables. Credentials in the proxy URL
+// are used for proxy authentication.
+func proxyFunc(u *url.URL) (func(*http.Request) (*url.URL, error), error) {
+	proxy, err := httpSetting(u, "proxy", "")
+	if err != nil {
+		return nil, err
+	}
+	if proxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("proxy: %v", err)
+	}
+	debug.Printf("Proxy: %s", proxyURL.Redacted())
+	return http.ProxyURL(proxyURL), nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// maxWaitIntervalFactor limits how much the poll interval may grow
+const maxWaitIntervalFactor = 4
+
+// waitBuildState polls the build state of the commit until no build is in
+// progress. Commits without any builds reported are waited for as well, as
+// the builds might not have started yet.
+func (s *subcommand) waitBuildState() int {
+	debug.Printf("Git ref: %s", s.ref())
+
+	commit, err := commitIDFromRef(s.ref())
+	if err != nil {
+		return checkError(err)
+	}
+	debug.Printf("Git commit: %s", commit)
+
+	deadline := time.Now().Add(s.timeout)
+	interval := s.interval
+	for {
+		bs, err := s.provider.BuildStatus(commit)
+		if err != nil {
+			return checkError(err)
+		}
+
+		stat := bs.Stat()
+		if stat.terminal() {
+			s.printBuildState(commit, bs)
+			return stat.exitCode()
+		}
+
+		remaining := deadline.Sub(time.Now())
+		if remaining <= 0 {
+			log.Printf("Timed out after %s waiting for builds of %s: %s", s.timeout, commit.abbrevCommit(), stat)
+			return exitTimeout
+		}
+
+		if interval > remaining {
+			interval = remaining
+		}
+		log.Printf("Waiting for builds of %s: %s, next check in %s", commit.abbrevCommit(), stat, interval.Round(100*time.Millisecond))
+		time.Sleep(interval)
+
+		interval = interval * 3 / 2
+		if max := s.interval * maxWaitIntervalFactor; interval > max {
+			interval = max
+		}
+	}
+}